@@ -6,14 +6,19 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"strings"
 
 	"github.com/VirtusLab/jenkins-operator/pkg/apis"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkinsagent"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkinsjob"
 	"github.com/VirtusLab/jenkins-operator/pkg/event"
 	"github.com/VirtusLab/jenkins-operator/pkg/log"
+	"github.com/VirtusLab/jenkins-operator/pkg/metrics"
+	"github.com/VirtusLab/jenkins-operator/pkg/watch"
+	"github.com/VirtusLab/jenkins-operator/pkg/webhook"
 	"github.com/VirtusLab/jenkins-operator/version"
 
-	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	"github.com/operator-framework/operator-sdk/pkg/leader"
 	"github.com/operator-framework/operator-sdk/pkg/ready"
 	sdkVersion "github.com/operator-framework/operator-sdk/version"
@@ -23,6 +28,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/runtime/signals"
 )
 
+const operatorNamespaceEnvVar = "OPERATOR_NAMESPACE"
+
 func printInfo() {
 	log.Log.Info(fmt.Sprintf("Version: %s", version.Version))
 	log.Log.Info(fmt.Sprintf("Git commit: %s", version.GitCommit))
@@ -35,16 +42,23 @@ func main() {
 	minikube := flag.Bool("minikube", false, "Use minikube as a Kubernetes platform")
 	local := flag.Bool("local", false, "Run operator locally")
 	debug := flag.Bool("debug", false, "Set log level to debug")
+	enableWebhook := flag.Bool("webhook", false, "Run admission webhook server which validates and sets defaults of Jenkins CR")
+	webhookPort := flag.Int("webhook-port", webhook.DefaultPort, "Port of admission webhook server")
+	metricsPort := flag.Int("metrics-port", metrics.DefaultPort, "Port of Prometheus metrics endpoint")
 	flag.Parse()
 
 	log.SetupLogger(debug)
 	printInfo()
 
-	namespace, err := k8sutil.GetWatchNamespace()
+	namespaces, err := watch.GetNamespaces()
 	if err != nil {
 		fatal(err, "failed to get watch namespace")
 	}
-	log.Log.Info(fmt.Sprintf("watch namespace: %v", namespace))
+	if len(namespaces) == 0 {
+		log.Log.Info("watch namespace: all namespaces")
+	} else {
+		log.Log.Info(fmt.Sprintf("watch namespace: %v", strings.Join(namespaces, ",")))
+	}
 
 	// get a config to talk to the apiserver
 	cfg, err := config.GetConfig()
@@ -68,7 +82,10 @@ func main() {
 	}()
 
 	// create a new Cmd to provide shared dependencies and start components
-	mgr, err := manager.New(cfg, manager.Options{Namespace: namespace})
+	mgr, err := manager.New(cfg, manager.Options{
+		Namespace:          watch.CacheNamespace(namespaces),
+		MetricsBindAddress: fmt.Sprintf(":%d", *metricsPort),
+	})
 	if err != nil {
 		fatal(err, "failed to create manager")
 	}
@@ -87,10 +104,31 @@ func main() {
 	}
 
 	// setup Jenkins controller
-	if err := jenkins.Add(mgr, *local, *minikube, events); err != nil {
+	if err := jenkins.Add(mgr, *local, *minikube, events, namespaces); err != nil {
+		fatal(err, "failed to setup controllers")
+	}
+
+	// setup JenkinsAgent controller
+	if err := jenkinsagent.Add(mgr, *local, *minikube, events, namespaces); err != nil {
+		fatal(err, "failed to setup controllers")
+	}
+
+	// setup JenkinsJob controller
+	if err := jenkinsjob.Add(mgr, *local, *minikube, events, namespaces); err != nil {
 		fatal(err, "failed to setup controllers")
 	}
 
+	// setup admission webhook
+	if *enableWebhook {
+		operatorNamespace, err := getOperatorNamespace(namespaces)
+		if err != nil {
+			fatal(err, "failed to get operator namespace")
+		}
+		if err := webhook.Add(mgr, operatorNamespace, int32(*webhookPort)); err != nil {
+			fatal(err, "failed to setup webhook server")
+		}
+	}
+
 	log.Log.Info("Starting the Cmd.")
 
 	// start the Cmd
@@ -99,6 +137,19 @@ func main() {
 	}
 }
 
+// getOperatorNamespace returns namespace of the operator pod from OPERATOR_NAMESPACE environment variable,
+// it defaults to the watch namespace when the operator watches a single namespace
+func getOperatorNamespace(namespaces []string) (string, error) {
+	if namespace, found := os.LookupEnv(operatorNamespaceEnvVar); found && len(namespace) > 0 {
+		return namespace, nil
+	}
+	if len(namespaces) == 1 {
+		return namespaces[0], nil
+	}
+
+	return "", fmt.Errorf("%s must be set when the operator doesn't watch a single namespace", operatorNamespaceEnvVar)
+}
+
 func fatal(err error, message string) {
 	log.Log.Error(err, message)
 	os.Exit(-1)
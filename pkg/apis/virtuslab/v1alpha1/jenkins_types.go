@@ -12,12 +12,88 @@ import (
 type JenkinsSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
+	// +kubebuilder:validation:Enum=NoBackup,AmazonS3
 	Backup         JenkinsBackup         `json:"backup,omitempty"`
 	BackupAmazonS3 JenkinsBackupAmazonS3 `json:"backupAmazonS3,omitempty"`
-	Master         JenkinsMaster         `json:"master,omitempty"`
-	SeedJobs       []SeedJob             `json:"seedJobs,omitempty"`
+	// +kubebuilder:validation:Enum=Retain,Delete
+	BackupDeletionPolicy BackupDeletionPolicy `json:"backupDeletionPolicy,omitempty"`
+	Master               JenkinsMaster        `json:"master,omitempty"`
+	Agents               JenkinsAgents        `json:"agents,omitempty"`
+	SeedJobs             []SeedJob            `json:"seedJobs,omitempty"`
+	// +kubebuilder:validation:Enum=Retain,Delete
+	SeedJobsRemovalPolicy SeedJobsRemovalPolicy `json:"seedJobsRemovalPolicy,omitempty"`
+	// JobDSLScriptSecurity enables Job DSL script security in Jenkins, it's a global setting so scripts of all seed jobs
+	// have to run in Groovy sandbox or be approved in ScriptApproval
+	JobDSLScriptSecurity bool                `json:"jobDslScriptSecurity,omitempty"`
+	ConfigurationAsCode  ConfigurationAsCode `json:"configurationAsCode,omitempty"`
+	UserConfiguration    UserConfiguration   `json:"userConfiguration,omitempty"`
+	Credentials          []Credential        `json:"credentials,omitempty"`
+	ScriptApproval       ScriptApproval      `json:"scriptApproval,omitempty"`
+	Views                []View              `json:"views,omitempty"`
+	Monitoring           JenkinsMonitoring   `json:"monitoring,omitempty"`
 }
 
+// JenkinsMonitoring defines monitoring of Jenkins master by Prometheus
+type JenkinsMonitoring struct {
+	// Enabled installs prometheus plugin in Jenkins and creates ServiceMonitor when Prometheus Operator is installed
+	Enabled bool `json:"enabled,omitempty"`
+	// ServiceMonitorLabels are added to ServiceMonitor, Prometheus selects ServiceMonitors by labels
+	ServiceMonitorLabels map[string]string `json:"serviceMonitorLabels,omitempty"`
+}
+
+// View defines Jenkins list view or nested view, views are managed by the operator
+// so views removed from Jenkins CR are deleted from Jenkins
+type View struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=list,nested
+	Type        ViewType `json:"type,omitempty"`
+	Description string   `json:"description,omitempty"`
+	// IncludeRegex selects jobs of list view by regular expression
+	IncludeRegex string `json:"includeRegex,omitempty"`
+	// Jobs contains names of jobs of list view
+	Jobs []string `json:"jobs,omitempty"`
+	// Recurse tells that list view includes jobs from folders
+	Recurse bool `json:"recurse,omitempty"`
+	// Views contains views of nested view
+	Views []View `json:"views,omitempty"`
+}
+
+// ViewType defines type of Jenkins view
+type ViewType string
+
+const (
+	// ListViewType is the list view, used when type is not set
+	ListViewType ViewType = "list"
+	// NestedViewType is the view grouping other views, requires nested-view plugin
+	NestedViewType ViewType = "nested"
+)
+
+// AllowedViewTypes contains all allowed view types
+var AllowedViewTypes = []ViewType{ListViewType, NestedViewType}
+
+// ScriptApproval defines scripts and signatures approved in Jenkins script security, the list is managed by the operator
+// so entries removed from the list are revoked, scripts and signatures approved manually in Jenkins are left untouched
+type ScriptApproval struct {
+	// ApprovedScriptHashes contains SHA-1 hashes of approved scripts as shown by Jenkins In-process Script Approval page
+	ApprovedScriptHashes []string `json:"approvedScriptHashes,omitempty"`
+	// ApprovedSignatures contains approved method signatures, for example "method java.lang.String trim"
+	ApprovedSignatures []string `json:"approvedSignatures,omitempty"`
+}
+
+// SeedJobsRemovalPolicy defines what happens with jobs generated by seed job removed from Jenkins CR,
+// the seed job and its credential are always deleted
+type SeedJobsRemovalPolicy string
+
+const (
+	// RetainSeedJobsRemovalPolicy tells that jobs generated by removed seed job are left in Jenkins
+	RetainSeedJobsRemovalPolicy SeedJobsRemovalPolicy = "Retain"
+	// DeleteSeedJobsRemovalPolicy tells that jobs generated by removed seed job are deleted from Jenkins
+	DeleteSeedJobsRemovalPolicy SeedJobsRemovalPolicy = "Delete"
+)
+
+// AllowedSeedJobsRemovalPolicies contains all allowed seed jobs removal policies
+var AllowedSeedJobsRemovalPolicies = []SeedJobsRemovalPolicy{RetainSeedJobsRemovalPolicy, DeleteSeedJobsRemovalPolicy}
+
 // JenkinsBackup defines type of Jenkins backup
 type JenkinsBackup string
 
@@ -31,6 +107,19 @@ const (
 // AllowedJenkinsBackups consists allowed Jenkins backup types
 var AllowedJenkinsBackups = []JenkinsBackup{JenkinsBackupTypeNoBackup, JenkinsBackupTypeAmazonS3}
 
+// BackupDeletionPolicy defines what happens with backup when Jenkins CR is deleted
+type BackupDeletionPolicy string
+
+const (
+	// RetainBackupDeletionPolicy tells that final backup is taken and backup data is left in the storage
+	RetainBackupDeletionPolicy BackupDeletionPolicy = "Retain"
+	// DeleteBackupDeletionPolicy tells that backup data is deleted from the storage
+	DeleteBackupDeletionPolicy BackupDeletionPolicy = "Delete"
+)
+
+// AllowedBackupDeletionPolicies contains all allowed backup deletion policies
+var AllowedBackupDeletionPolicies = []BackupDeletionPolicy{RetainBackupDeletionPolicy, DeleteBackupDeletionPolicy}
+
 // JenkinsBackupAmazonS3 defines backup configuration to AWS S3 bucket
 type JenkinsBackupAmazonS3 struct {
 	BucketName string `json:"bucketName,omitempty"`
@@ -41,20 +130,194 @@ type JenkinsBackupAmazonS3 struct {
 // JenkinsMaster defines the Jenkins master pod attributes and plugins,
 // every single change requires Jenkins master pod restart
 type JenkinsMaster struct {
+	// +kubebuilder:validation:Pattern=^([\w][\w.-]{0,127}|([a-zA-Z0-9.-]+(:[0-9]+)?/)?[a-z0-9]+([._-]+[a-z0-9]+)*(/[a-z0-9]+([._-]+[a-z0-9]+)*)*(:[\w][\w.-]{0,127})?(@[A-Za-z][A-Za-z0-9]*([-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,})?)$
 	Image       string                      `json:"image,omitempty"`
 	Annotations map[string]string           `json:"masterAnnotations,omitempty"`
 	Resources   corev1.ResourceRequirements `json:"resources,omitempty"`
 	Plugins     map[string][]string         `json:"plugins,omitempty"`
 }
 
+// JenkinsAgents defines Jenkins agents launched as Kubernetes pods by Kubernetes plugin,
+// pod templates are applied to the Kubernetes cloud configured by the operator
+type JenkinsAgents struct {
+	PodTemplates []PodTemplate `json:"podTemplates,omitempty"`
+}
+
+// PodTemplate defines Kubernetes plugin pod template, jobs select the template by one of its labels
+type PodTemplate struct {
+	Name               string              `json:"name"`
+	Labels             []string            `json:"labels,omitempty"`
+	Containers         []ContainerTemplate `json:"containers"`
+	Volumes            []PodTemplateVolume `json:"volumes,omitempty"`
+	ServiceAccountName string              `json:"serviceAccountName,omitempty"`
+	// IdleMinutes defines how long the agent pod is kept after the build, 0 means it's deleted right after the build
+	// +kubebuilder:validation:Minimum=0
+	IdleMinutes int `json:"idleMinutes,omitempty"`
+}
+
+// ContainerTemplate defines container of agent pod, container named jnlp replaces the default JNLP agent container
+type ContainerTemplate struct {
+	Name       string                      `json:"name"`
+	Image      string                      `json:"image"`
+	Command    string                      `json:"command,omitempty"`
+	Args       string                      `json:"args,omitempty"`
+	WorkingDir string                      `json:"workingDir,omitempty"`
+	Resources  corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// PodTemplateVolume defines volume mounted in all containers of agent pod,
+// at most one of config map, secret and persistent volume claim can be set, empty dir is used when none of them is set
+type PodTemplateVolume struct {
+	MountPath                 string `json:"mountPath"`
+	ConfigMapName             string `json:"configMapName,omitempty"`
+	SecretName                string `json:"secretName,omitempty"`
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName,omitempty"`
+	ReadOnly                  bool   `json:"readOnly,omitempty"`
+}
+
+// ConfigurationAsCode defines configuration of Jenkins customization via Configuration as Code Jenkins plugin,
+// all YAML files from the referenced config maps are applied and the secret is used to resolve ${VARIABLE} references
+type ConfigurationAsCode struct {
+	Configurations []ConfigMapRef `json:"configurations,omitempty"`
+	Secret         SecretRef      `json:"secret,omitempty"`
+}
+
+// UserConfiguration defines additional sources of groovy scripts used to configure Jenkins,
+// sources are applied in the given order after scripts from the jenkins-operator-user-configuration config map,
+// secrets are mounted in Jenkins master pod and exposed to groovy scripts as secrets['secret-name']['key']
+type UserConfiguration struct {
+	Sources []UserConfigurationSource `json:"sources,omitempty"`
+	Secrets []SecretRef               `json:"secrets,omitempty"`
+}
+
+// UserConfigurationSource defines config map or secret with groovy scripts, exactly one of them must be set
+type UserConfigurationSource struct {
+	ConfigMapRef *ConfigMapRef `json:"configMapRef,omitempty"`
+	SecretRef    *SecretRef    `json:"secretRef,omitempty"`
+}
+
+// CredentialType defines type of Jenkins credential
+type CredentialType string
+
+const (
+	// UsernamePasswordCredentialType is the username with password credential, secret keys: username, password
+	UsernamePasswordCredentialType CredentialType = "usernamePassword"
+	// SecretTextCredentialType is the secret text credential, secret keys: secret
+	SecretTextCredentialType CredentialType = "secretText"
+	// SSHKeyCredentialType is the SSH username with private key credential, secret keys: username, privateKey, passphrase(optional)
+	SSHKeyCredentialType CredentialType = "sshKey"
+	// SecretFileCredentialType is the secret file credential, secret keys: fileName, data
+	SecretFileCredentialType CredentialType = "secretFile"
+	// CertificateCredentialType is the PKCS#12 certificate credential, secret keys: certificate, password(optional)
+	CertificateCredentialType CredentialType = "certificate"
+)
+
+// AllowedCredentialTypes contains all allowed Jenkins credential types
+var AllowedCredentialTypes = []CredentialType{
+	UsernamePasswordCredentialType,
+	SecretTextCredentialType,
+	SSHKeyCredentialType,
+	SecretFileCredentialType,
+	CertificateCredentialType,
+}
+
+// Credential defines Jenkins credential synchronized from Kubernetes secret
+type Credential struct {
+	// +kubebuilder:validation:Pattern=^[a-zA-Z0-9][-_a-zA-Z0-9]*$
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	// +kubebuilder:validation:Enum=usernamePassword,secretText,sshKey,secretFile,certificate
+	Type      CredentialType `json:"type"`
+	SecretRef SecretRef      `json:"secretRef"`
+}
+
+// ConfigMapRef is the reference to Kubernetes config map in the same namespace as Jenkins CR
+type ConfigMapRef struct {
+	Name string `json:"name"`
+}
+
+// SecretRef is the reference to Kubernetes secret in the same namespace as Jenkins CR
+type SecretRef struct {
+	Name string `json:"name"`
+}
+
 // JenkinsStatus defines the observed state of Jenkins
 type JenkinsStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
-	BackupRestored                 bool         `json:"backupRestored,omitempty"`
-	BaseConfigurationCompletedTime *metav1.Time `json:"baseConfigurationCompletedTime,omitempty"`
-	UserConfigurationCompletedTime *metav1.Time `json:"userConfigurationCompletedTime,omitempty"`
-	Builds                         []Build      `json:"builds,omitempty"`
+	BackupRestored                 bool            `json:"backupRestored,omitempty"`
+	BaseConfigurationCompletedTime *metav1.Time    `json:"baseConfigurationCompletedTime,omitempty"`
+	UserConfigurationCompletedTime *metav1.Time    `json:"userConfigurationCompletedTime,omitempty"`
+	Builds                         []Build         `json:"builds,omitempty"`
+	ConfigurationAsCodeError       string          `json:"configurationAsCodeError,omitempty"`
+	SeedJobs                       []SeedJobStatus `json:"seedJobs,omitempty"`
+	// ObservedGeneration is the most recent generation of Jenkins CR reconciled by the operator
+	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
+	Conditions         []JenkinsCondition `json:"conditions,omitempty"`
+	// Phase is a summary of conditions shown by kubectl get jenkins
+	// +kubebuilder:validation:Enum=Initializing,Configuring,Running,Failed
+	Phase JenkinsPhase `json:"phase,omitempty"`
+	// Image is the image of Jenkins master container, including the default image which isn't saved in Jenkins CR spec
+	Image string `json:"image,omitempty"`
+}
+
+// JenkinsPhase is a high level summary of where Jenkins is in its lifecycle
+type JenkinsPhase string
+
+const (
+	// JenkinsInitializingPhase tells that Jenkins master pod is being created and base configuration is being applied
+	JenkinsInitializingPhase JenkinsPhase = "Initializing"
+	// JenkinsConfiguringPhase tells that base configuration is applied and user configuration is being applied
+	JenkinsConfiguringPhase JenkinsPhase = "Configuring"
+	// JenkinsRunningPhase tells that Jenkins is ready
+	JenkinsRunningPhase JenkinsPhase = "Running"
+	// JenkinsFailedPhase tells that Jenkins CR is invalid or the last reconciliation failed
+	JenkinsFailedPhase JenkinsPhase = "Failed"
+)
+
+// JenkinsConditionType is a type of Jenkins condition
+type JenkinsConditionType string
+
+const (
+	// JenkinsReady tells that base and user configuration is applied and the last reconciliation succeeded
+	JenkinsReady JenkinsConditionType = "Ready"
+	// JenkinsBaseConfigured tells that Jenkins master pod is running and base configuration is applied
+	JenkinsBaseConfigured JenkinsConditionType = "BaseConfigured"
+	// JenkinsUserConfigured tells that user configuration is applied
+	JenkinsUserConfigured JenkinsConditionType = "UserConfigured"
+	// JenkinsBackupHealthy tells that backup has been restored, backup job is configured and its last build succeeded
+	JenkinsBackupHealthy JenkinsConditionType = "BackupHealthy"
+	// JenkinsPluginsInSync tells that plugins installed in Jenkins match the required plugins
+	JenkinsPluginsInSync JenkinsConditionType = "PluginsInSync"
+	// JenkinsDegraded tells that the last reconciliation failed
+	JenkinsDegraded JenkinsConditionType = "Degraded"
+	// JenkinsScriptsApproved tells that no scripts or signatures are waiting for approval in Jenkins script security
+	JenkinsScriptsApproved JenkinsConditionType = "ScriptsApproved"
+)
+
+// JenkinsCondition describes state of Jenkins at a certain point
+type JenkinsCondition struct {
+	Type JenkinsConditionType `json:"type"`
+	// +kubebuilder:validation:Enum=True,False,Unknown
+	Status corev1.ConditionStatus `json:"status"`
+	// Reason is a CamelCase reason of the last transition
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the last time the condition changed its status
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// SeedJobStatus defines observed state of seed job, build fields describe the last completed build of Job DSL seed job
+// or the failed build which configures seed job
+type SeedJobStatus struct {
+	ID            string       `json:"id"`
+	LastCommitSHA string       `json:"lastCommitSHA,omitempty"`
+	LastRunTime   *metav1.Time `json:"lastRunTime,omitempty"`
+	BuildNumber   int64        `json:"buildNumber,omitempty"`
+	// +kubebuilder:validation:Enum=success,unstable,not_build,not_built,failure,aborted,running,expired
+	Result            BuildStatus `json:"result,omitempty"`
+	GeneratedJobCount int         `json:"generatedJobCount,omitempty"`
+	ErrorMessage      string      `json:"errorMessage,omitempty"`
 }
 
 // BuildStatus defines type of Jenkins build job status
@@ -77,13 +340,16 @@ const (
 	BuildExpiredStatus BuildStatus = "expired"
 )
 
-// Build defines Jenkins Build status with corresponding metadata
+// Build defines Jenkins Build status with corresponding metadata,
+// Key identifies the configuration item built by a job shared by many items, e.g. seed job ID
 type Build struct {
-	JobName        string       `json:"jobName,omitempty"`
-	Hash           string       `json:"hash,omitempty"`
-	Number         int64        `json:"number,omitempty"`
+	JobName string `json:"jobName,omitempty"`
+	Key     string `json:"key,omitempty"`
+	Hash    string `json:"hash,omitempty"`
+	Number  int64  `json:"number,omitempty"`
+	// +kubebuilder:validation:Enum=success,unstable,not_build,not_built,failure,aborted,running,expired
 	Status         BuildStatus  `json:"status,omitempty"`
-	Retires        int          `json:"retries,omitempty"`
+	Retries        int          `json:"retries,omitempty"`
 	CreateTime     *metav1.Time `json:"createTime,omitempty"`
 	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
 }
@@ -92,6 +358,12 @@ type Build struct {
 
 // Jenkins is the Schema for the jenkins API
 // +k8s:openapi-gen=true
+// +kubebuilder:resource:path=jenkins
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".status.image"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=="Ready")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type Jenkins struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -109,19 +381,124 @@ type JenkinsList struct {
 	Items           []Jenkins `json:"items"`
 }
 
-// SeedJob defined configuration for seed jobs and deploy keys
+// SeedJob defined configuration for seed jobs and deploy keys,
+// Job DSL scripts of seed job with Sandbox set run in Groovy sandbox, which requires JenkinsSpec.JobDSLScriptSecurity
 type SeedJob struct {
-	ID               string     `json:"id"`
-	Description      string     `json:"description,omitempty"`
-	Targets          string     `json:"targets,omitempty"`
-	RepositoryBranch string     `json:"repositoryBranch,omitempty"`
-	RepositoryURL    string     `json:"repositoryUrl"`
-	PrivateKey       PrivateKey `json:"privateKey,omitempty"`
+	// +kubebuilder:validation:MinLength=1
+	ID               string `json:"id"`
+	Description      string `json:"description,omitempty"`
+	Targets          string `json:"targets,omitempty"`
+	RepositoryBranch string `json:"repositoryBranch,omitempty"`
+	RepositoryURL    string `json:"repositoryUrl,omitempty"`
+	// +kubebuilder:validation:Enum=none,sshKey,usernamePassword,token
+	CredentialType   SeedJobCredentialType `json:"credentialType,omitempty"`
+	PrivateKey       PrivateKey            `json:"privateKey,omitempty"`
+	UsernamePassword UsernamePassword      `json:"usernamePassword,omitempty"`
+	Token            Token                 `json:"token,omitempty"`
+	Triggers         SeedJobTriggers       `json:"triggers,omitempty"`
+	// +kubebuilder:validation:Enum=jobDsl,multibranch,organizationFolder
+	Type               SeedJobType        `json:"type,omitempty"`
+	Multibranch        Multibranch        `json:"multibranch,omitempty"`
+	OrganizationFolder OrganizationFolder `json:"organizationFolder,omitempty"`
+	Sandbox            bool               `json:"sandbox,omitempty"`
+}
+
+// SeedJobType defines how jobs are created from seed job repository
+type SeedJobType string
+
+const (
+	// JobDSLSeedJobType runs Job DSL scripts from Targets, default seed job type
+	JobDSLSeedJobType SeedJobType = "jobDsl"
+	// MultibranchSeedJobType creates Multibranch Pipeline job for repository, requires workflow-multibranch plugin
+	MultibranchSeedJobType SeedJobType = "multibranch"
+	// OrganizationFolderSeedJobType creates Organization Folder for all repositories of GitHub organization or Bitbucket team,
+	// requires github-branch-source or cloudbees-bitbucket-branch-source plugin
+	OrganizationFolderSeedJobType SeedJobType = "organizationFolder"
+)
+
+// AllowedSeedJobTypes contains all allowed seed job types
+var AllowedSeedJobTypes = []SeedJobType{JobDSLSeedJobType, MultibranchSeedJobType, OrganizationFolderSeedJobType}
+
+// Multibranch defines options of Multibranch Pipeline job created for multibranch seed job
+type Multibranch struct {
+	// ScriptPath is the path to Jenkinsfile in repository, defaults to Jenkinsfile
+	ScriptPath string `json:"scriptPath,omitempty"`
+}
+
+// OrganizationProvider defines Git server hosting repositories of organization folder
+type OrganizationProvider string
+
+const (
+	// GitHubOrganizationProvider scans GitHub organization or user
+	GitHubOrganizationProvider OrganizationProvider = "github"
+	// BitbucketOrganizationProvider scans Bitbucket team or project
+	BitbucketOrganizationProvider OrganizationProvider = "bitbucket"
+)
+
+// AllowedOrganizationProviders contains all allowed organization folder providers
+var AllowedOrganizationProviders = []OrganizationProvider{GitHubOrganizationProvider, BitbucketOrganizationProvider}
+
+// OrganizationFolder defines options of Organization Folder created for organizationFolder seed job
+type OrganizationFolder struct {
+	Provider OrganizationProvider `json:"provider"`
+	// Owner is the name of GitHub organization or user, or Bitbucket team
+	Owner string `json:"owner"`
+	// ServerURL is the API URL of GitHub Enterprise or URL of Bitbucket Server, public servers are used when empty
+	ServerURL string `json:"serverUrl,omitempty"`
+	// ScriptPath is the path to Jenkinsfile in repositories, defaults to Jenkinsfile
+	ScriptPath string `json:"scriptPath,omitempty"`
+}
+
+// SeedJobTriggers defines when seed job is re-run to pick up new commits from its repository,
+// push triggers require github, gitlab-plugin or bitbucket Jenkins plugin and webhook configured in the Git server
+type SeedJobTriggers struct {
+	// PollSCM is the cron expression used to poll repository for changes, for example "H/5 * * * *"
+	PollSCM       string `json:"pollSCM,omitempty"`
+	GitHubPush    bool   `json:"githubPush,omitempty"`
+	GitLabPush    bool   `json:"gitlabPush,omitempty"`
+	BitbucketPush bool   `json:"bitbucketPush,omitempty"`
 }
 
-// PrivateKey contains a private key
+// SeedJobCredentialType defines type of credential used to access seed job repository
+type SeedJobCredentialType string
+
+const (
+	// NoSeedJobCredentialType is used for public repositories
+	NoSeedJobCredentialType SeedJobCredentialType = "none"
+	// SSHKeySeedJobCredentialType is the SSH deploy key from PrivateKey, default when PrivateKey is set
+	SSHKeySeedJobCredentialType SeedJobCredentialType = "sshKey"
+	// UsernamePasswordSeedJobCredentialType is the username and password from UsernamePassword used with HTTPS repository
+	UsernamePasswordSeedJobCredentialType SeedJobCredentialType = "usernamePassword"
+	// TokenSeedJobCredentialType is the personal access token from Token used with HTTPS repository
+	TokenSeedJobCredentialType SeedJobCredentialType = "token"
+)
+
+// AllowedSeedJobCredentialTypes contains all allowed seed job credential types
+var AllowedSeedJobCredentialTypes = []SeedJobCredentialType{
+	NoSeedJobCredentialType,
+	SSHKeySeedJobCredentialType,
+	UsernamePasswordSeedJobCredentialType,
+	TokenSeedJobCredentialType,
+}
+
+// PrivateKey contains a private key in OpenSSH, PKCS#1, PKCS#8 or SEC 1 format (RSA, ECDSA and Ed25519 keys),
+// passphrase of encrypted private key is read from PassphraseKey of the same secret
 type PrivateKey struct {
-	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef"`
+	SecretKeyRef  *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+	PassphraseKey string                    `json:"passphraseKey,omitempty"`
+}
+
+// UsernamePassword contains references to username and password
+type UsernamePassword struct {
+	UsernameSecretKeyRef *corev1.SecretKeySelector `json:"usernameSecretKeyRef,omitempty"`
+	PasswordSecretKeyRef *corev1.SecretKeySelector `json:"passwordSecretKeyRef,omitempty"`
+}
+
+// Token contains a reference to personal access token, username is optional because most of Git servers accept
+// any username with token
+type Token struct {
+	Username     string                    `json:"username,omitempty"`
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
 }
 
 func init() {
@@ -0,0 +1,82 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JenkinsAgentSpec defines the desired state of JenkinsAgent
+type JenkinsAgentSpec struct {
+	// JenkinsRef is a name of Jenkins CR in the same namespace, the agent is registered in its Jenkins instance
+	JenkinsRef string `json:"jenkinsRef"`
+	// Name is a Jenkins node name, JenkinsAgent name is used when it's not set
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	Executors   int      `json:"executors,omitempty"`
+	RemoteFS    string   `json:"remoteFS"`
+	// Launcher defines how Jenkins master connects to the agent, JNLP is used when it's not set
+	Launcher AgentLauncherType `json:"launcher,omitempty"`
+	SSH      SSHLauncher       `json:"ssh,omitempty"`
+}
+
+// AgentLauncherType defines how Jenkins master connects to the agent
+type AgentLauncherType string
+
+const (
+	// JNLPAgentLauncherType tells that the agent connects to Jenkins master using JNLP
+	JNLPAgentLauncherType AgentLauncherType = "jnlp"
+	// SSHAgentLauncherType tells that Jenkins master connects to the agent over SSH, requires ssh-slaves plugin
+	SSHAgentLauncherType AgentLauncherType = "ssh"
+)
+
+// AllowedAgentLauncherTypes contains all allowed agent launcher types
+var AllowedAgentLauncherTypes = []AgentLauncherType{JNLPAgentLauncherType, SSHAgentLauncherType}
+
+// SSHLauncher defines SSH connection to the agent, the credential must be defined in spec.credentials of Jenkins CR
+type SSHLauncher struct {
+	Host          string `json:"host"`
+	Port          int    `json:"port,omitempty"`
+	CredentialsID string `json:"credentialsId"`
+	JavaPath      string `json:"javaPath,omitempty"`
+	JVMOptions    string `json:"jvmOptions,omitempty"`
+}
+
+// JenkinsAgentStatus defines the observed state of JenkinsAgent
+type JenkinsAgentStatus struct {
+	Registered bool `json:"registered,omitempty"`
+	Online     bool `json:"online,omitempty"`
+	// OfflineReason is a reason why the agent is offline reported by Jenkins
+	OfflineReason  string       `json:"offlineReason,omitempty"`
+	Hash           string       `json:"hash,omitempty"`
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+	// JenkinsRef is a name of Jenkins CR the node is registered in
+	JenkinsRef string `json:"jenkinsRef,omitempty"`
+	// NodeName is a name of the node registered in Jenkins
+	NodeName string `json:"nodeName,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JenkinsAgent is the Schema for the jenkinsagents API, it defines static agent node of Jenkins instance
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+type JenkinsAgent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   JenkinsAgentSpec   `json:"spec,omitempty"`
+	Status JenkinsAgentStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JenkinsAgentList contains a list of JenkinsAgent
+type JenkinsAgentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JenkinsAgent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&JenkinsAgent{}, &JenkinsAgentList{})
+}
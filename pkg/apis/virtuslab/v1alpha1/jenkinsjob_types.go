@@ -0,0 +1,70 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JenkinsJobSpec defines the desired state of JenkinsJob, exactly one of ConfigXML and Pipeline must be set
+type JenkinsJobSpec struct {
+	// JenkinsRef is a name of Jenkins CR in the same namespace, the job is created in its Jenkins instance
+	JenkinsRef string `json:"jenkinsRef"`
+	// Name is a Jenkins job name, JenkinsJob name is used when it's not set
+	Name string `json:"name,omitempty"`
+	// Folders is a path of folders the job is created in, missing folders are created
+	Folders []string `json:"folders,omitempty"`
+	// ConfigXML is a job configuration in Jenkins config.xml format
+	ConfigXML string              `json:"configXml,omitempty"`
+	Pipeline  *JenkinsJobPipeline `json:"pipeline,omitempty"`
+}
+
+// JenkinsJobPipeline defines Pipeline job, the script is read from config map or from Git repository,
+// exactly one of ScriptConfigMapRef and RepositoryURL must be set
+type JenkinsJobPipeline struct {
+	Description        string                       `json:"description,omitempty"`
+	ScriptConfigMapRef *corev1.ConfigMapKeySelector `json:"scriptConfigMapRef,omitempty"`
+	Sandbox            bool                         `json:"sandbox,omitempty"`
+	RepositoryURL      string                       `json:"repositoryUrl,omitempty"`
+	RepositoryBranch   string                       `json:"repositoryBranch,omitempty"`
+	ScriptPath         string                       `json:"scriptPath,omitempty"`
+	// CredentialsID is an ID of credential used to access the repository, defined in spec.credentials of Jenkins CR
+	CredentialsID string `json:"credentialsId,omitempty"`
+}
+
+// JenkinsJobStatus defines the observed state of JenkinsJob, Path is the path of the job created in Jenkins
+type JenkinsJobStatus struct {
+	Created         bool         `json:"created,omitempty"`
+	Path            string       `json:"path,omitempty"`
+	Hash            string       `json:"hash,omitempty"`
+	LastBuildNumber int64        `json:"lastBuildNumber,omitempty"`
+	LastBuildResult BuildStatus  `json:"lastBuildResult,omitempty"`
+	LastBuildTime   *metav1.Time `json:"lastBuildTime,omitempty"`
+	// JenkinsRef is a name of Jenkins CR the job has been created in
+	JenkinsRef string `json:"jenkinsRef,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JenkinsJob is the Schema for the jenkinsjobs API, it defines a single job of Jenkins instance
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+type JenkinsJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   JenkinsJobSpec   `json:"spec,omitempty"`
+	Status JenkinsJobStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JenkinsJobList contains a list of JenkinsJob
+type JenkinsJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JenkinsJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&JenkinsJob{}, &JenkinsJobList{})
+}
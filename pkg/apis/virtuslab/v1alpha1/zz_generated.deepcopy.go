@@ -49,6 +49,78 @@ func (in *Build) DeepCopy() *Build {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapRef) DeepCopyInto(out *ConfigMapRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapRef.
+func (in *ConfigMapRef) DeepCopy() *ConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigurationAsCode) DeepCopyInto(out *ConfigurationAsCode) {
+	*out = *in
+	if in.Configurations != nil {
+		in, out := &in.Configurations, &out.Configurations
+		*out = make([]ConfigMapRef, len(*in))
+		copy(*out, *in)
+	}
+	out.Secret = in.Secret
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationAsCode.
+func (in *ConfigurationAsCode) DeepCopy() *ConfigurationAsCode {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigurationAsCode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerTemplate) DeepCopyInto(out *ContainerTemplate) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerTemplate.
+func (in *ContainerTemplate) DeepCopy() *ContainerTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Credential) DeepCopyInto(out *Credential) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Credential.
+func (in *Credential) DeepCopy() *Credential {
+	if in == nil {
+		return nil
+	}
+	out := new(Credential)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Jenkins) DeepCopyInto(out *Jenkins) {
 	*out = *in
@@ -77,6 +149,132 @@ func (in *Jenkins) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsAgent) DeepCopyInto(out *JenkinsAgent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsAgent.
+func (in *JenkinsAgent) DeepCopy() *JenkinsAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsAgent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsAgentList) DeepCopyInto(out *JenkinsAgentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JenkinsAgent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsAgentList.
+func (in *JenkinsAgentList) DeepCopy() *JenkinsAgentList {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsAgentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsAgentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsAgentSpec) DeepCopyInto(out *JenkinsAgentSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.SSH = in.SSH
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsAgentSpec.
+func (in *JenkinsAgentSpec) DeepCopy() *JenkinsAgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsAgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsAgentStatus) DeepCopyInto(out *JenkinsAgentStatus) {
+	*out = *in
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsAgentStatus.
+func (in *JenkinsAgentStatus) DeepCopy() *JenkinsAgentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsAgentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsAgents) DeepCopyInto(out *JenkinsAgents) {
+	*out = *in
+	if in.PodTemplates != nil {
+		in, out := &in.PodTemplates, &out.PodTemplates
+		*out = make([]PodTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsAgents.
+func (in *JenkinsAgents) DeepCopy() *JenkinsAgents {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsAgents)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JenkinsBackupAmazonS3) DeepCopyInto(out *JenkinsBackupAmazonS3) {
 	*out = *in
@@ -93,6 +291,151 @@ func (in *JenkinsBackupAmazonS3) DeepCopy() *JenkinsBackupAmazonS3 {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsCondition) DeepCopyInto(out *JenkinsCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsCondition.
+func (in *JenkinsCondition) DeepCopy() *JenkinsCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsJob) DeepCopyInto(out *JenkinsJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsJob.
+func (in *JenkinsJob) DeepCopy() *JenkinsJob {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsJobList) DeepCopyInto(out *JenkinsJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JenkinsJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsJobList.
+func (in *JenkinsJobList) DeepCopy() *JenkinsJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsJobPipeline) DeepCopyInto(out *JenkinsJobPipeline) {
+	*out = *in
+	if in.ScriptConfigMapRef != nil {
+		in, out := &in.ScriptConfigMapRef, &out.ScriptConfigMapRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsJobPipeline.
+func (in *JenkinsJobPipeline) DeepCopy() *JenkinsJobPipeline {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsJobPipeline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsJobSpec) DeepCopyInto(out *JenkinsJobSpec) {
+	*out = *in
+	if in.Folders != nil {
+		in, out := &in.Folders, &out.Folders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Pipeline != nil {
+		in, out := &in.Pipeline, &out.Pipeline
+		*out = new(JenkinsJobPipeline)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsJobSpec.
+func (in *JenkinsJobSpec) DeepCopy() *JenkinsJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsJobStatus) DeepCopyInto(out *JenkinsJobStatus) {
+	*out = *in
+	if in.LastBuildTime != nil {
+		in, out := &in.LastBuildTime, &out.LastBuildTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsJobStatus.
+func (in *JenkinsJobStatus) DeepCopy() *JenkinsJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JenkinsList) DeepCopyInto(out *JenkinsList) {
 	*out = *in
@@ -165,11 +508,35 @@ func (in *JenkinsMaster) DeepCopy() *JenkinsMaster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsMonitoring) DeepCopyInto(out *JenkinsMonitoring) {
+	*out = *in
+	if in.ServiceMonitorLabels != nil {
+		in, out := &in.ServiceMonitorLabels, &out.ServiceMonitorLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsMonitoring.
+func (in *JenkinsMonitoring) DeepCopy() *JenkinsMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JenkinsSpec) DeepCopyInto(out *JenkinsSpec) {
 	*out = *in
 	out.BackupAmazonS3 = in.BackupAmazonS3
 	in.Master.DeepCopyInto(&out.Master)
+	in.Agents.DeepCopyInto(&out.Agents)
 	if in.SeedJobs != nil {
 		in, out := &in.SeedJobs, &out.SeedJobs
 		*out = make([]SeedJob, len(*in))
@@ -177,6 +544,22 @@ func (in *JenkinsSpec) DeepCopyInto(out *JenkinsSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.ConfigurationAsCode.DeepCopyInto(&out.ConfigurationAsCode)
+	in.UserConfiguration.DeepCopyInto(&out.UserConfiguration)
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = make([]Credential, len(*in))
+		copy(*out, *in)
+	}
+	in.ScriptApproval.DeepCopyInto(&out.ScriptApproval)
+	if in.Views != nil {
+		in, out := &in.Views, &out.Views
+		*out = make([]View, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Monitoring.DeepCopyInto(&out.Monitoring)
 	return
 }
 
@@ -208,6 +591,20 @@ func (in *JenkinsStatus) DeepCopyInto(out *JenkinsStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SeedJobs != nil {
+		in, out := &in.SeedJobs, &out.SeedJobs
+		*out = make([]SeedJobStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]JenkinsCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -221,6 +618,87 @@ func (in *JenkinsStatus) DeepCopy() *JenkinsStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Multibranch) DeepCopyInto(out *Multibranch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Multibranch.
+func (in *Multibranch) DeepCopy() *Multibranch {
+	if in == nil {
+		return nil
+	}
+	out := new(Multibranch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationFolder) DeepCopyInto(out *OrganizationFolder) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationFolder.
+func (in *OrganizationFolder) DeepCopy() *OrganizationFolder {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationFolder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplate) DeepCopyInto(out *PodTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ContainerTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]PodTemplateVolume, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplate.
+func (in *PodTemplate) DeepCopy() *PodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplateVolume) DeepCopyInto(out *PodTemplateVolume) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplateVolume.
+func (in *PodTemplateVolume) DeepCopy() *PodTemplateVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplateVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateKey) DeepCopyInto(out *PrivateKey) {
 	*out = *in
@@ -242,10 +720,73 @@ func (in *PrivateKey) DeepCopy() *PrivateKey {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHLauncher) DeepCopyInto(out *SSHLauncher) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHLauncher.
+func (in *SSHLauncher) DeepCopy() *SSHLauncher {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHLauncher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScriptApproval) DeepCopyInto(out *ScriptApproval) {
+	*out = *in
+	if in.ApprovedScriptHashes != nil {
+		in, out := &in.ApprovedScriptHashes, &out.ApprovedScriptHashes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApprovedSignatures != nil {
+		in, out := &in.ApprovedSignatures, &out.ApprovedSignatures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptApproval.
+func (in *ScriptApproval) DeepCopy() *ScriptApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(ScriptApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretRef) DeepCopyInto(out *SecretRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRef.
+func (in *SecretRef) DeepCopy() *SecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SeedJob) DeepCopyInto(out *SeedJob) {
 	*out = *in
 	in.PrivateKey.DeepCopyInto(&out.PrivateKey)
+	in.UsernamePassword.DeepCopyInto(&out.UsernamePassword)
+	in.Token.DeepCopyInto(&out.Token)
+	out.Triggers = in.Triggers
+	out.Multibranch = in.Multibranch
+	out.OrganizationFolder = in.OrganizationFolder
 	return
 }
 
@@ -258,3 +799,168 @@ func (in *SeedJob) DeepCopy() *SeedJob {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedJobStatus) DeepCopyInto(out *SeedJobStatus) {
+	*out = *in
+	if in.LastRunTime != nil {
+		in, out := &in.LastRunTime, &out.LastRunTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedJobStatus.
+func (in *SeedJobStatus) DeepCopy() *SeedJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedJobTriggers) DeepCopyInto(out *SeedJobTriggers) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedJobTriggers.
+func (in *SeedJobTriggers) DeepCopy() *SeedJobTriggers {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedJobTriggers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Token) DeepCopyInto(out *Token) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Token.
+func (in *Token) DeepCopy() *Token {
+	if in == nil {
+		return nil
+	}
+	out := new(Token)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserConfiguration) DeepCopyInto(out *UserConfiguration) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]UserConfigurationSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SecretRef, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserConfiguration.
+func (in *UserConfiguration) DeepCopy() *UserConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(UserConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserConfigurationSource) DeepCopyInto(out *UserConfigurationSource) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(ConfigMapRef)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserConfigurationSource.
+func (in *UserConfigurationSource) DeepCopy() *UserConfigurationSource {
+	if in == nil {
+		return nil
+	}
+	out := new(UserConfigurationSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsernamePassword) DeepCopyInto(out *UsernamePassword) {
+	*out = *in
+	if in.UsernameSecretKeyRef != nil {
+		in, out := &in.UsernameSecretKeyRef, &out.UsernameSecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecretKeyRef != nil {
+		in, out := &in.PasswordSecretKeyRef, &out.PasswordSecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsernamePassword.
+func (in *UsernamePassword) DeepCopy() *UsernamePassword {
+	if in == nil {
+		return nil
+	}
+	out := new(UsernamePassword)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *View) DeepCopyInto(out *View) {
+	*out = *in
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Views != nil {
+		in, out := &in.Views, &out.Views
+		*out = make([]View, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new View.
+func (in *View) DeepCopy() *View {
+	if in == nil {
+		return nil
+	}
+	out := new(View)
+	in.DeepCopyInto(out)
+	return out
+}
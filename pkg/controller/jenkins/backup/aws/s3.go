@@ -8,11 +8,10 @@ import (
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/base/resources"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/plugins"
-	"github.com/VirtusLab/jenkins-operator/pkg/log"
 
-	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	k8s "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -187,46 +186,102 @@ node(&apos;master&apos;) {
 </flow-definition>`, nil
 }
 
-// IsConfigurationValidForBasePhase validates if user provided valid configuration of backup for base phase
-func (b *AmazonS3Backup) IsConfigurationValidForBasePhase(jenkins virtuslabv1alpha1.Jenkins, logger logr.Logger) bool {
+// GetDeleteBackupJobXML returns Jenkins job config XML used to delete all backups stored under the bucket path
+func (b *AmazonS3Backup) GetDeleteBackupJobXML(jenkins virtuslabv1alpha1.Jenkins) (string, error) {
+	return `<?xml version='1.1' encoding='UTF-8'?>
+<flow-definition plugin="workflow-job@2.31">
+  <actions/>
+  <description></description>
+  <keepDependencies>false</keepDependencies>
+  <properties>
+    <org.jenkinsci.plugins.workflow.job.properties.DisableConcurrentBuildsJobProperty/>
+    <org.jenkinsci.plugins.workflow.job.properties.DisableResumeJobProperty/>
+  </properties>
+  <definition class="org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition" plugin="workflow-cps@2.61.1">
+    <script>import com.amazonaws.auth.PropertiesFileCredentialsProvider
+import com.amazonaws.services.s3.AmazonS3ClientBuilder
+import com.amazonaws.services.s3.model.ObjectListing
+
+node(&apos;master&apos;) {
+    def accessKeyFilePath = &quot;` + resources.JenkinsBackupCredentialsVolumePath + `/` + constants.BackupAmazonS3SecretAccessKey + `&quot;
+    def secretKeyFilePath = &quot;` + resources.JenkinsBackupCredentialsVolumePath + `/` + constants.BackupAmazonS3SecretSecretKey + `&quot;
+    def credentialsFileName = &quot;backup-credentials&quot;
+    def bucketName = &quot;` + jenkins.Spec.BackupAmazonS3.BucketName + `&quot;
+    def bucketKey = &quot;` + jenkins.Spec.BackupAmazonS3.BucketPath + `&quot;
+    def region = &quot;` + jenkins.Spec.BackupAmazonS3.Region + `&quot;
+
+    def accessKey = new java.io.File(accessKeyFilePath).text
+    def secretKey = new java.io.File(secretKeyFilePath).text
+	sh &quot;touch ${env.WORKSPACE}/${credentialsFileName}&quot;
+    new java.io.File(&quot;${env.WORKSPACE}/${credentialsFileName}&quot;).write(&quot;accessKey=${accessKey}\nsecretKey=${secretKey}\n&quot;)
+
+    stage(&apos;Delete backups&apos;) {
+        def s3 = AmazonS3ClientBuilder
+                .standard()
+                .withCredentials(new PropertiesFileCredentialsProvider(&quot;${env.WORKSPACE}/${credentialsFileName}&quot;))
+                .withRegion(region)
+                .build()
+        ObjectListing listing = s3.listObjects(bucketName, &quot;${bucketKey}/&quot;)
+        while (true) {
+            for (summary in listing.getObjectSummaries()) {
+                println &quot;Deleting backup ${bucketName}/${summary.getKey()}&quot;
+                s3.deleteObject(bucketName, summary.getKey())
+            }
+            if (!listing.isTruncated()) {
+                break
+            }
+            listing = s3.listNextBatchOfObjects(listing)
+        }
+    }
+
+	sh &quot;rm ${env.WORKSPACE}/${credentialsFileName}&quot;
+}</script>
+    <sandbox>false</sandbox>
+  </definition>
+  <triggers/>
+  <disabled>false</disabled>
+</flow-definition>`, nil
+}
+
+// ValidateForBasePhase validates if user provided valid configuration of backup for base phase
+func (b *AmazonS3Backup) ValidateForBasePhase(jenkins virtuslabv1alpha1.Jenkins) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec", "backupAmazonS3")
+
 	if len(jenkins.Spec.BackupAmazonS3.BucketName) == 0 {
-		logger.V(log.VWarn).Info("Bucket name not set in 'spec.backupAmazonS3.bucketName'")
-		return false
+		allErrs = append(allErrs, field.Required(fldPath.Child("bucketName"), "bucket name not set"))
 	}
 
 	if len(jenkins.Spec.BackupAmazonS3.BucketPath) == 0 {
-		logger.V(log.VWarn).Info("Bucket path not set in 'spec.backupAmazonS3.bucketPath'")
-		return false
+		allErrs = append(allErrs, field.Required(fldPath.Child("bucketPath"), "bucket path not set"))
 	}
 
 	if len(jenkins.Spec.BackupAmazonS3.Region) == 0 {
-		logger.V(log.VWarn).Info("Region not set in 'spec.backupAmazonS3.region'")
-		return false
+		allErrs = append(allErrs, field.Required(fldPath.Child("region"), "region not set"))
 	}
 
-	return true
+	return allErrs
 }
 
-// IsConfigurationValidForUserPhase validates if user provided valid configuration of backup for user phase
-func (b *AmazonS3Backup) IsConfigurationValidForUserPhase(k8sClient k8s.Client, jenkins virtuslabv1alpha1.Jenkins, logger logr.Logger) (bool, error) {
+// ValidateForUserPhase validates if user provided valid configuration of backup for user phase
+func (b *AmazonS3Backup) ValidateForUserPhase(k8sClient k8s.Client, jenkins virtuslabv1alpha1.Jenkins) (field.ErrorList, error) {
 	backupSecretName := resources.GetBackupCredentialsSecretName(&jenkins)
 	backupSecret := &corev1.Secret{}
 	err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: backupSecretName}, backupSecret)
 	if err != nil {
-		return false, err
-	}
-
-	if len(backupSecret.Data[constants.BackupAmazonS3SecretSecretKey]) == 0 {
-		logger.V(log.VWarn).Info(fmt.Sprintf("Secret '%s' doesn't contains key: %s", backupSecretName, constants.BackupAmazonS3SecretSecretKey))
-		return false, nil
+		return nil, err
 	}
 
-	if len(backupSecret.Data[constants.BackupAmazonS3SecretAccessKey]) == 0 {
-		logger.V(log.VWarn).Info(fmt.Sprintf("Secret '%s' doesn't contains key: %s", backupSecretName, constants.BackupAmazonS3SecretAccessKey))
-		return false, nil
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec", "backup")
+	for _, key := range []string{constants.BackupAmazonS3SecretSecretKey, constants.BackupAmazonS3SecretAccessKey} {
+		if len(backupSecret.Data[key]) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath,
+				fmt.Sprintf("secret '%s' doesn't contain key: %s", backupSecretName, key)))
+		}
 	}
 
-	return true, nil
+	return allErrs, nil
 }
 
 // GetRequiredPlugins returns all required Jenkins plugins by this backup strategy
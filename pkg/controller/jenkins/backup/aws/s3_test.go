@@ -11,10 +11,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
-	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 )
 
-func TestAmazonS3Backup_IsConfigurationValidForBasePhase(t *testing.T) {
+func TestAmazonS3Backup_ValidateForBasePhase(t *testing.T) {
 	tests := []struct {
 		name    string
 		jenkins virtuslabv1alpha1.Jenkins
@@ -76,13 +75,13 @@ func TestAmazonS3Backup_IsConfigurationValidForBasePhase(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &AmazonS3Backup{}
-			got := r.IsConfigurationValidForBasePhase(tt.jenkins, logf.ZapLogger(false))
-			assert.Equal(t, tt.want, got)
+			errs := r.ValidateForBasePhase(tt.jenkins)
+			assert.Equal(t, tt.want, len(errs) == 0, errs)
 		})
 	}
 }
 
-func TestAmazonS3Backup_IsConfigurationValidForUserPhase(t *testing.T) {
+func TestAmazonS3Backup_ValidateForUserPhase(t *testing.T) {
 	tests := []struct {
 		name    string
 		jenkins *virtuslabv1alpha1.Jenkins
@@ -147,19 +146,18 @@ func TestAmazonS3Backup_IsConfigurationValidForUserPhase(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			k8sClient := fake.NewFakeClient()
-			logger := logf.ZapLogger(false)
 			b := &AmazonS3Backup{}
 			if tt.secret != nil {
 				e := k8sClient.Create(context.TODO(), tt.secret)
 				assert.NoError(t, e)
 			}
-			got, err := b.IsConfigurationValidForUserPhase(k8sClient, *tt.jenkins, logger)
+			errs, err := b.ValidateForUserPhase(k8sClient, *tt.jenkins)
 			if tt.wantErr {
 				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
+				return
 			}
-			assert.Equal(t, tt.want, got)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, len(errs) == 0, errs)
 		})
 	}
 }
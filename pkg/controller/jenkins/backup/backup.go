@@ -1,7 +1,6 @@
 package backup
 
 import (
-	"context"
 	"fmt"
 	"time"
 
@@ -9,27 +8,36 @@ import (
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/backup/aws"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/backup/nobackup"
 	jenkinsclient "github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/client"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/conditions"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/base/resources"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/jobs"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/plugins"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/status"
+	"github.com/VirtusLab/jenkins-operator/pkg/log"
+	"github.com/VirtusLab/jenkins-operator/pkg/metrics"
 
+	"github.com/bndr/gojenkins"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	k8s "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
-	restoreJobName = constants.OperatorName + "-restore-backup"
+	restoreJobName      = constants.OperatorName + "-restore-backup"
+	deleteBackupJobName = constants.OperatorName + "-delete-backup"
 )
 
 // Provider defines API of backup providers
 type Provider interface {
 	GetRestoreJobXML(jenkins virtuslabv1alpha1.Jenkins) (string, error)
 	GetBackupJobXML(jenkins virtuslabv1alpha1.Jenkins) (string, error)
-	IsConfigurationValidForBasePhase(jenkins virtuslabv1alpha1.Jenkins, logger logr.Logger) bool
-	IsConfigurationValidForUserPhase(k8sClient k8s.Client, jenkins virtuslabv1alpha1.Jenkins, logger logr.Logger) (bool, error)
+	GetDeleteBackupJobXML(jenkins virtuslabv1alpha1.Jenkins) (string, error)
+	ValidateForBasePhase(jenkins virtuslabv1alpha1.Jenkins) field.ErrorList
+	ValidateForUserPhase(k8sClient k8s.Client, jenkins virtuslabv1alpha1.Jenkins) (field.ErrorList, error)
 	GetRequiredPlugins() map[string][]plugins.Plugin
 }
 
@@ -87,6 +95,8 @@ func (b *Backup) RestoreBackup() (reconcile.Result, error) {
 			if err == jobs.ErrorUnrecoverableBuildFailed {
 				b.logger.Info(fmt.Sprintf("Restore backup can not be performed. Please check backup configuration in CR and credentials in secret '%s'.", resources.GetBackupCredentialsSecretName(b.jenkins)))
 				b.logger.Info(fmt.Sprintf("You can also check '%s' job logs in Jenkins", constants.BackupJobName))
+				conditions.Set(&b.jenkins.Status, virtuslabv1alpha1.JenkinsBackupHealthy, corev1.ConditionFalse, conditions.ReasonRestoreFailed,
+					fmt.Sprintf("Restore backup failed, check '%s' job logs in Jenkins", restoreJobName))
 				return reconcile.Result{}, nil
 			}
 			// unexpected error - requeue reconciliation loop
@@ -98,7 +108,7 @@ func (b *Backup) RestoreBackup() (reconcile.Result, error) {
 		}
 
 		b.jenkins.Status.BackupRestored = true
-		err = b.k8sClient.Update(context.TODO(), b.jenkins)
+		err = status.Update(b.k8sClient, b.jenkins)
 		return reconcile.Result{}, err
 	}
 
@@ -115,17 +125,106 @@ func (b *Backup) EnsureBackupJob() error {
 	if err != nil {
 		return err
 	}
-	_, created, err := b.jenkinsClient.CreateOrUpdateJob(backupJobXML, constants.BackupJobName)
+	job, created, err := b.jenkinsClient.CreateOrUpdateJob(backupJobXML, constants.BackupJobName)
 	if err != nil {
 		return err
 	}
 	if created {
 		b.logger.Info(fmt.Sprintf("'%s' job has been created", constants.BackupJobName))
 	}
+	lastBackupFailed := false
+	if b.jenkins.Spec.Backup != virtuslabv1alpha1.JenkinsBackupTypeNoBackup && job != nil && job.Raw != nil {
+		lastBackupFailed = isLastBackupFailed(job.Raw)
+		if err := b.observeLastBackup(job.Raw); err != nil {
+			b.logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't get the last backup: %s", err))
+		}
+	}
+
+	// restore is run only once, keep reporting its failure
+	if condition := conditions.Get(b.jenkins.Status, virtuslabv1alpha1.JenkinsBackupHealthy); condition != nil && condition.Reason == conditions.ReasonRestoreFailed {
+		return nil
+	}
+	if b.jenkins.Spec.Backup == virtuslabv1alpha1.JenkinsBackupTypeNoBackup {
+		conditions.Set(&b.jenkins.Status, virtuslabv1alpha1.JenkinsBackupHealthy, corev1.ConditionFalse, conditions.ReasonBackupDisabled,
+			"Backup is disabled, configure it in spec.backup")
+		return nil
+	}
+	if lastBackupFailed {
+		conditions.Set(&b.jenkins.Status, virtuslabv1alpha1.JenkinsBackupHealthy, corev1.ConditionFalse, conditions.ReasonBackupFailed,
+			fmt.Sprintf("The last backup build #%d failed, check '%s' job logs in Jenkins", job.Raw.LastCompletedBuild.Number, constants.BackupJobName))
+		return nil
+	}
+	conditions.Set(&b.jenkins.Status, virtuslabv1alpha1.JenkinsBackupHealthy, corev1.ConditionTrue, conditions.ReasonBackupConfigured, "")
 
 	return nil
 }
 
+// isLastBackupFailed tells whether the last completed build of backup job isn't the last successful one
+func isLastBackupFailed(job *gojenkins.JobResponse) bool {
+	return job.LastCompletedBuild.Number != job.LastSuccessfulBuild.Number
+}
+
+// observeLastBackup records result of the last completed build of backup job and time of the last successful one
+func (b *Backup) observeLastBackup(job *gojenkins.JobResponse) error {
+	if job.LastCompletedBuild.Number == 0 {
+		return nil
+	}
+
+	var lastSuccess time.Time
+	if job.LastSuccessfulBuild.Number > 0 {
+		build, err := b.jenkinsClient.GetBuild(constants.BackupJobName, job.LastSuccessfulBuild.Number)
+		if err != nil {
+			return err
+		}
+		lastSuccess = build.GetTimestamp()
+	}
+	metrics.SetBackup(b.jenkins, !isLastBackupFailed(job), lastSuccess)
+
+	return nil
+}
+
+// EnsureFinalBackup takes the last backup or deletes backup data according to Jenkins.Spec.BackupDeletionPolicy,
+// it's called when Jenkins CR is being deleted and returns true when there is nothing more to do
+func (b *Backup) EnsureFinalBackup() (bool, error) {
+	if len(b.jenkins.Spec.Backup) == 0 || b.jenkins.Spec.Backup == virtuslabv1alpha1.JenkinsBackupTypeNoBackup {
+		return true, nil
+	}
+
+	provider, err := GetBackupProvider(b.jenkins.Spec.Backup)
+	if err != nil {
+		return false, err
+	}
+
+	jobName, hash := constants.BackupJobName, "hash-final-backup"
+	jobXML, err := provider.GetBackupJobXML(*b.jenkins)
+	if b.jenkins.Spec.BackupDeletionPolicy == virtuslabv1alpha1.DeleteBackupDeletionPolicy {
+		jobName, hash = deleteBackupJobName, "hash-delete-backup"
+		jobXML, err = provider.GetDeleteBackupJobXML(*b.jenkins)
+	} else if b.jenkins.Status.UserConfigurationCompletedTime == nil {
+		// backup hasn't been restored yet, the final backup would overwrite the latest one
+		b.logger.Info("Skipping final backup, Jenkins hasn't been configured yet")
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	_, created, err := b.jenkinsClient.CreateOrUpdateJob(jobXML, jobName)
+	if err != nil {
+		return false, err
+	}
+	if created {
+		b.logger.Info(fmt.Sprintf("'%s' job has been created", jobName))
+	}
+
+	jobsClient := jobs.New(b.jenkinsClient, b.k8sClient, b.logger)
+	done, err := jobsClient.EnsureBuildJob(jobName, hash, map[string]string{}, b.jenkins, true)
+	// build failed and can be recovered - retry build
+	if err == jobs.ErrorBuildFailed {
+		return false, nil
+	}
+	return done, err
+}
+
 // GetBackupProvider returns backup provider by type
 func GetBackupProvider(backupType virtuslabv1alpha1.JenkinsBackup) (Provider, error) {
 	switch backupType {
@@ -0,0 +1,227 @@
+package backup
+
+import (
+	"context"
+	"testing"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/client"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/conditions"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
+
+	"github.com/bndr/gojenkins"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func TestBackup_EnsureFinalBackup(t *testing.T) {
+	err := virtuslabv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+
+	t.Run("no backup", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkins := jenkinsCustomResource(virtuslabv1alpha1.JenkinsBackupTypeNoBackup, virtuslabv1alpha1.DeleteBackupDeletionPolicy)
+		backup := New(jenkins, fake.NewFakeClient(), logf.ZapLogger(false), client.NewMockJenkins(ctrl))
+
+		done, err := backup.EnsureFinalBackup()
+
+		assert.NoError(t, err)
+		assert.True(t, done)
+	})
+	t.Run("retain, Jenkins not configured yet", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkins := jenkinsCustomResource(virtuslabv1alpha1.JenkinsBackupTypeAmazonS3, virtuslabv1alpha1.RetainBackupDeletionPolicy)
+		jenkins.Status.UserConfigurationCompletedTime = nil
+		backup := New(jenkins, fake.NewFakeClient(), logf.ZapLogger(false), client.NewMockJenkins(ctrl))
+
+		done, err := backup.EnsureFinalBackup()
+
+		assert.NoError(t, err)
+		assert.True(t, done)
+	})
+	t.Run("retain", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkins := jenkinsCustomResource(virtuslabv1alpha1.JenkinsBackupTypeAmazonS3, virtuslabv1alpha1.RetainBackupDeletionPolicy)
+		fakeClient := fake.NewFakeClient()
+		assert.NoError(t, fakeClient.Create(context.TODO(), jenkins))
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().CreateOrUpdateJob(gomock.Any(), constants.BackupJobName).Return(nil, false, nil)
+		jenkinsClient.EXPECT().GetJob(constants.BackupJobName).
+			Return(&gojenkins.Job{Raw: &gojenkins.JobResponse{NextBuildNumber: 1}}, nil)
+		jenkinsClient.EXPECT().BuildJob(constants.BackupJobName, gomock.Any()).Return(int64(0), nil)
+		backup := New(jenkins, fakeClient, logf.ZapLogger(false), jenkinsClient)
+
+		done, err := backup.EnsureFinalBackup()
+
+		assert.NoError(t, err)
+		assert.False(t, done)
+		assert.Equal(t, constants.BackupJobName, jenkins.Status.Builds[0].JobName)
+		assert.Equal(t, virtuslabv1alpha1.BuildRunningStatus, jenkins.Status.Builds[0].Status)
+	})
+	t.Run("delete", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkins := jenkinsCustomResource(virtuslabv1alpha1.JenkinsBackupTypeAmazonS3, virtuslabv1alpha1.DeleteBackupDeletionPolicy)
+		jenkins.Status.Builds = []virtuslabv1alpha1.Build{
+			{JobName: deleteBackupJobName, Hash: "hash-delete-backup", Number: 1, Status: virtuslabv1alpha1.BuildRunningStatus},
+		}
+		fakeClient := fake.NewFakeClient()
+		assert.NoError(t, fakeClient.Create(context.TODO(), jenkins))
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().CreateOrUpdateJob(gomock.Any(), deleteBackupJobName).Return(nil, true, nil)
+		jenkinsClient.EXPECT().GetBuild(deleteBackupJobName, int64(1)).
+			Return(&gojenkins.Build{Raw: &gojenkins.BuildResponse{Result: string(virtuslabv1alpha1.BuildSuccessStatus)}}, nil)
+		backup := New(jenkins, fakeClient, logf.ZapLogger(false), jenkinsClient)
+
+		done, err := backup.EnsureFinalBackup()
+
+		assert.NoError(t, err)
+		assert.True(t, done)
+	})
+}
+
+func TestBackup_EnsureBackupJob(t *testing.T) {
+	t.Run("no backup yet", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkins := jenkinsCustomResource(virtuslabv1alpha1.JenkinsBackupTypeAmazonS3, "")
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().CreateOrUpdateJob(gomock.Any(), constants.BackupJobName).
+			Return(&gojenkins.Job{Raw: &gojenkins.JobResponse{}}, false, nil)
+		backup := New(jenkins, fake.NewFakeClient(), logf.ZapLogger(false), jenkinsClient)
+
+		err := backup.EnsureBackupJob()
+
+		assert.NoError(t, err)
+		assert.True(t, conditions.IsTrue(jenkins.Status, virtuslabv1alpha1.JenkinsBackupHealthy))
+	})
+	t.Run("last backup succeeded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkins := jenkinsCustomResource(virtuslabv1alpha1.JenkinsBackupTypeAmazonS3, "")
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().CreateOrUpdateJob(gomock.Any(), constants.BackupJobName).
+			Return(&gojenkins.Job{Raw: &gojenkins.JobResponse{
+				LastCompletedBuild:  gojenkins.JobBuild{Number: 3},
+				LastSuccessfulBuild: gojenkins.JobBuild{Number: 3},
+			}}, false, nil)
+		jenkinsClient.EXPECT().GetBuild(constants.BackupJobName, int64(3)).
+			Return(&gojenkins.Build{Raw: &gojenkins.BuildResponse{Timestamp: 1546300800000}}, nil)
+		backup := New(jenkins, fake.NewFakeClient(), logf.ZapLogger(false), jenkinsClient)
+
+		err := backup.EnsureBackupJob()
+
+		assert.NoError(t, err)
+		condition := conditions.Get(jenkins.Status, virtuslabv1alpha1.JenkinsBackupHealthy)
+		assert.NotNil(t, condition)
+		assert.Equal(t, corev1.ConditionTrue, condition.Status)
+		assert.Equal(t, conditions.ReasonBackupConfigured, condition.Reason)
+	})
+	t.Run("last backup failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkins := jenkinsCustomResource(virtuslabv1alpha1.JenkinsBackupTypeAmazonS3, "")
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().CreateOrUpdateJob(gomock.Any(), constants.BackupJobName).
+			Return(&gojenkins.Job{Raw: &gojenkins.JobResponse{
+				LastCompletedBuild:  gojenkins.JobBuild{Number: 3},
+				LastSuccessfulBuild: gojenkins.JobBuild{Number: 2},
+			}}, false, nil)
+		jenkinsClient.EXPECT().GetBuild(constants.BackupJobName, int64(2)).
+			Return(&gojenkins.Build{Raw: &gojenkins.BuildResponse{Timestamp: 1546300800000}}, nil)
+		backup := New(jenkins, fake.NewFakeClient(), logf.ZapLogger(false), jenkinsClient)
+
+		err := backup.EnsureBackupJob()
+
+		assert.NoError(t, err)
+		condition := conditions.Get(jenkins.Status, virtuslabv1alpha1.JenkinsBackupHealthy)
+		assert.NotNil(t, condition)
+		assert.Equal(t, corev1.ConditionFalse, condition.Status)
+		assert.Equal(t, conditions.ReasonBackupFailed, condition.Reason)
+	})
+}
+
+func TestBackup_observeLastBackup(t *testing.T) {
+	t.Run("no backup yet", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkins := jenkinsCustomResource(virtuslabv1alpha1.JenkinsBackupTypeAmazonS3, "")
+		backup := New(jenkins, fake.NewFakeClient(), logf.ZapLogger(false), client.NewMockJenkins(ctrl))
+
+		err := backup.observeLastBackup(&gojenkins.JobResponse{})
+
+		assert.NoError(t, err)
+	})
+	t.Run("last backup failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkins := jenkinsCustomResource(virtuslabv1alpha1.JenkinsBackupTypeAmazonS3, "")
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetBuild(constants.BackupJobName, int64(2)).
+			Return(&gojenkins.Build{Raw: &gojenkins.BuildResponse{Timestamp: 1546300800000}}, nil)
+		backup := New(jenkins, fake.NewFakeClient(), logf.ZapLogger(false), jenkinsClient)
+
+		err := backup.observeLastBackup(&gojenkins.JobResponse{
+			LastCompletedBuild:  gojenkins.JobBuild{Number: 3},
+			LastSuccessfulBuild: gojenkins.JobBuild{Number: 2},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, gaugeValue(t, "jenkins_operator_backup_success", jenkins))
+		assert.Equal(t, 1546300800.0, gaugeValue(t, "jenkins_operator_backup_last_success_timestamp_seconds", jenkins))
+	})
+}
+
+// gaugeValue returns value of the gauge of Jenkins CR registered by metrics package
+func gaugeValue(t *testing.T, name string, jenkins *virtuslabv1alpha1.Jenkins) float64 {
+	families, err := crmetrics.Registry.Gather()
+	assert.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, pair := range metric.GetLabel() {
+				labels[pair.GetName()] = pair.GetValue()
+			}
+			if labels["namespace"] == jenkins.Namespace && labels["jenkins"] == jenkins.Name {
+				return metric.GetGauge().GetValue()
+			}
+		}
+	}
+	t.Fatalf("gauge '%s' of Jenkins '%s' not found", name, jenkins.Name)
+	return 0
+}
+
+func jenkinsCustomResource(backupType virtuslabv1alpha1.JenkinsBackup, policy virtuslabv1alpha1.BackupDeletionPolicy) *virtuslabv1alpha1.Jenkins {
+	now := metav1.Now()
+	return &virtuslabv1alpha1.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jenkins",
+			Namespace: "default",
+		},
+		Spec: virtuslabv1alpha1.JenkinsSpec{
+			Backup: backupType,
+			BackupAmazonS3: virtuslabv1alpha1.JenkinsBackupAmazonS3{
+				BucketName: "bucket",
+				BucketPath: "jenkins",
+				Region:     "eu-west-1",
+			},
+			BackupDeletionPolicy: policy,
+		},
+		Status: virtuslabv1alpha1.JenkinsStatus{
+			BaseConfigurationCompletedTime: &now,
+			UserConfigurationCompletedTime: &now,
+		},
+	}
+}
@@ -4,7 +4,7 @@ import (
 	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/plugins"
 
-	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	k8s "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -36,14 +36,19 @@ func (b *NoBackup) GetBackupJobXML(jenkins virtuslabv1alpha1.Jenkins) (string, e
 	return emptyJob, nil
 }
 
-// IsConfigurationValidForBasePhase validates if user provided valid configuration of backup for base phase
-func (b *NoBackup) IsConfigurationValidForBasePhase(jenkins virtuslabv1alpha1.Jenkins, logger logr.Logger) bool {
-	return true
+// GetDeleteBackupJobXML returns Jenkins job config XML used to delete backup data
+func (b *NoBackup) GetDeleteBackupJobXML(jenkins virtuslabv1alpha1.Jenkins) (string, error) {
+	return emptyJob, nil
+}
+
+// ValidateForBasePhase validates if user provided valid configuration of backup for base phase
+func (b *NoBackup) ValidateForBasePhase(jenkins virtuslabv1alpha1.Jenkins) field.ErrorList {
+	return nil
 }
 
-// IsConfigurationValidForUserPhase validates if user provided valid configuration of backup for user phase
-func (b *NoBackup) IsConfigurationValidForUserPhase(k8sClient k8s.Client, jenkins virtuslabv1alpha1.Jenkins, logger logr.Logger) (bool, error) {
-	return true, nil
+// ValidateForUserPhase validates if user provided valid configuration of backup for user phase
+func (b *NoBackup) ValidateForUserPhase(k8sClient k8s.Client, jenkins virtuslabv1alpha1.Jenkins) (field.ErrorList, error) {
+	return nil, nil
 }
 
 // GetRequiredPlugins returns all required Jenkins plugins by this backup strategy
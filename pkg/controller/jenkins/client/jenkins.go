@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os/exec"
 	"strings"
 
@@ -51,6 +52,7 @@ type Jenkins interface {
 	GetAllViews() ([]*gojenkins.View, error)
 	CreateView(name string, viewType string) (*gojenkins.View, error)
 	Poll() (int, error)
+	ExecuteScript(script string) (string, error)
 }
 
 type jenkins struct {
@@ -61,7 +63,7 @@ type jenkins struct {
 func (jenkins *jenkins) CreateOrUpdateJob(config, jobName string) (job *gojenkins.Job, created bool, err error) {
 	// create or update
 	job, err = jenkins.GetJob(jobName)
-	if isNotFoundError(err) {
+	if IsNotFoundError(err) {
 		job, err = jenkins.CreateJob(config, jobName)
 		created = true
 		return
@@ -73,7 +75,25 @@ func (jenkins *jenkins) CreateOrUpdateJob(config, jobName string) (job *gojenkin
 	return
 }
 
-func isNotFoundError(err error) bool {
+// ExecuteScript runs groovy script in Jenkins script console and returns its output
+func (jenkins *jenkins) ExecuteScript(script string) (string, error) {
+	output := ""
+	data := url.Values{}
+	data.Set("script", script)
+
+	response, err := jenkins.Requester.Post("/scriptText", bytes.NewBufferString(data.Encode()), &output, map[string]string{})
+	if err != nil {
+		return "", errors.Wrap(err, "couldn't execute groovy script")
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", errors.Errorf("couldn't execute groovy script, invalid status code returned: %d", response.StatusCode)
+	}
+
+	return output, nil
+}
+
+// IsNotFoundError returns true when Jenkins API responded with 404 status code
+func IsNotFoundError(err error) bool {
 	if err != nil {
 		return err.Error() == errorNotFound.Error()
 	}
@@ -0,0 +1,102 @@
+// Package conditions implements managing conditions and phase of Jenkins CR status
+package conditions
+
+import (
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ReasonCompleted tells that configuration phase has been completed
+	ReasonCompleted = "Completed"
+	// ReasonInProgress tells that configuration phase is in progress
+	ReasonInProgress = "InProgress"
+	// ReasonValidationFailed tells that Jenkins CR is invalid
+	ReasonValidationFailed = "ValidationFailed"
+	// ReasonReconcileFailed tells that the last reconciliation failed
+	ReasonReconcileFailed = "ReconcileFailed"
+	// ReasonReconcileSucceeded tells that the last reconciliation succeeded
+	ReasonReconcileSucceeded = "ReconcileSucceeded"
+	// ReasonPluginsInstalled tells that all required plugins are installed
+	ReasonPluginsInstalled = "PluginsInstalled"
+	// ReasonMissingPlugins tells that some of required plugins are not installed
+	ReasonMissingPlugins = "MissingPlugins"
+	// ReasonBackupConfigured tells that backup job is configured
+	ReasonBackupConfigured = "BackupConfigured"
+	// ReasonBackupFailed tells that the last build of backup job failed
+	ReasonBackupFailed = "BackupFailed"
+	// ReasonBackupDisabled tells that backup is disabled in Jenkins CR
+	ReasonBackupDisabled = "BackupDisabled"
+	// ReasonRestoreFailed tells that backup couldn't be restored
+	ReasonRestoreFailed = "RestoreFailed"
+	// ReasonScriptsApproved tells that no scripts or signatures are waiting for approval
+	ReasonScriptsApproved = "ScriptsApproved"
+	// ReasonPendingScriptApproval tells that scripts or signatures are waiting for approval
+	ReasonPendingScriptApproval = "PendingScriptApproval"
+)
+
+// Get returns condition of given type or nil when it's not set
+func Get(status virtuslabv1alpha1.JenkinsStatus, conditionType virtuslabv1alpha1.JenkinsConditionType) *virtuslabv1alpha1.JenkinsCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == conditionType {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsTrue checks if condition of given type is set and its status is true
+func IsTrue(status virtuslabv1alpha1.JenkinsStatus, conditionType virtuslabv1alpha1.JenkinsConditionType) bool {
+	condition := Get(status, conditionType)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// Set sets condition of given type, last transition time is updated only when condition status changes.
+// It returns true when the condition has been changed
+func Set(status *virtuslabv1alpha1.JenkinsStatus, conditionType virtuslabv1alpha1.JenkinsConditionType,
+	conditionStatus corev1.ConditionStatus, reason, message string) bool {
+	condition := Get(*status, conditionType)
+	if condition == nil {
+		status.Conditions = append(status.Conditions, virtuslabv1alpha1.JenkinsCondition{
+			Type:               conditionType,
+			Status:             conditionStatus,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: metav1.Now(),
+		})
+		return true
+	}
+
+	if condition.Status == conditionStatus && condition.Reason == reason && condition.Message == message {
+		return false
+	}
+	if condition.Status != conditionStatus {
+		condition.Status = conditionStatus
+		condition.LastTransitionTime = metav1.Now()
+	}
+	condition.Reason = reason
+	condition.Message = message
+	return true
+}
+
+// Phase returns phase of Jenkins summarizing its conditions
+func Phase(status virtuslabv1alpha1.JenkinsStatus) virtuslabv1alpha1.JenkinsPhase {
+	for _, conditionType := range []virtuslabv1alpha1.JenkinsConditionType{virtuslabv1alpha1.JenkinsBaseConfigured, virtuslabv1alpha1.JenkinsUserConfigured} {
+		if condition := Get(status, conditionType); condition != nil && condition.Reason == ReasonValidationFailed {
+			return virtuslabv1alpha1.JenkinsFailedPhase
+		}
+	}
+
+	switch {
+	case IsTrue(status, virtuslabv1alpha1.JenkinsDegraded):
+		return virtuslabv1alpha1.JenkinsFailedPhase
+	case IsTrue(status, virtuslabv1alpha1.JenkinsReady):
+		return virtuslabv1alpha1.JenkinsRunningPhase
+	case IsTrue(status, virtuslabv1alpha1.JenkinsBaseConfigured):
+		return virtuslabv1alpha1.JenkinsConfiguringPhase
+	default:
+		return virtuslabv1alpha1.JenkinsInitializingPhase
+	}
+}
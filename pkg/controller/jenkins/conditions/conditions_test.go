@@ -0,0 +1,116 @@
+package conditions
+
+import (
+	"testing"
+	"time"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSet(t *testing.T) {
+	t.Run("new condition", func(t *testing.T) {
+		status := &virtuslabv1alpha1.JenkinsStatus{}
+
+		changed := Set(status, virtuslabv1alpha1.JenkinsReady, corev1.ConditionFalse, ReasonInProgress, "")
+
+		assert.True(t, changed)
+		assert.Len(t, status.Conditions, 1)
+		assert.False(t, IsTrue(*status, virtuslabv1alpha1.JenkinsReady))
+		assert.False(t, Get(*status, virtuslabv1alpha1.JenkinsReady).LastTransitionTime.IsZero())
+	})
+	t.Run("unchanged condition", func(t *testing.T) {
+		status := &virtuslabv1alpha1.JenkinsStatus{}
+		Set(status, virtuslabv1alpha1.JenkinsReady, corev1.ConditionTrue, ReasonCompleted, "")
+
+		changed := Set(status, virtuslabv1alpha1.JenkinsReady, corev1.ConditionTrue, ReasonCompleted, "")
+
+		assert.False(t, changed)
+		assert.Len(t, status.Conditions, 1)
+	})
+	t.Run("transition time is kept when only message changes", func(t *testing.T) {
+		lastTransitionTime := metav1.NewTime(metav1.Now().Add(-time.Hour))
+		status := &virtuslabv1alpha1.JenkinsStatus{
+			Conditions: []virtuslabv1alpha1.JenkinsCondition{
+				{Type: virtuslabv1alpha1.JenkinsDegraded, Status: corev1.ConditionTrue, Reason: ReasonReconcileFailed, Message: "first", LastTransitionTime: lastTransitionTime},
+			},
+		}
+
+		changed := Set(status, virtuslabv1alpha1.JenkinsDegraded, corev1.ConditionTrue, ReasonReconcileFailed, "second")
+
+		assert.True(t, changed)
+		condition := Get(*status, virtuslabv1alpha1.JenkinsDegraded)
+		assert.Equal(t, "second", condition.Message)
+		assert.Equal(t, lastTransitionTime, condition.LastTransitionTime)
+
+		changed = Set(status, virtuslabv1alpha1.JenkinsDegraded, corev1.ConditionFalse, ReasonReconcileSucceeded, "")
+
+		assert.True(t, changed)
+		condition = Get(*status, virtuslabv1alpha1.JenkinsDegraded)
+		assert.Equal(t, corev1.ConditionFalse, condition.Status)
+		assert.True(t, condition.LastTransitionTime.After(lastTransitionTime.Time))
+	})
+}
+
+func TestPhase(t *testing.T) {
+	data := []struct {
+		name       string
+		conditions []virtuslabv1alpha1.JenkinsCondition
+		expected   virtuslabv1alpha1.JenkinsPhase
+	}{
+		{
+			name:     "no conditions",
+			expected: virtuslabv1alpha1.JenkinsInitializingPhase,
+		},
+		{
+			name: "base configuration in progress",
+			conditions: []virtuslabv1alpha1.JenkinsCondition{
+				{Type: virtuslabv1alpha1.JenkinsBaseConfigured, Status: corev1.ConditionFalse, Reason: ReasonInProgress},
+			},
+			expected: virtuslabv1alpha1.JenkinsInitializingPhase,
+		},
+		{
+			name: "user configuration in progress",
+			conditions: []virtuslabv1alpha1.JenkinsCondition{
+				{Type: virtuslabv1alpha1.JenkinsBaseConfigured, Status: corev1.ConditionTrue, Reason: ReasonCompleted},
+				{Type: virtuslabv1alpha1.JenkinsUserConfigured, Status: corev1.ConditionFalse, Reason: ReasonInProgress},
+			},
+			expected: virtuslabv1alpha1.JenkinsConfiguringPhase,
+		},
+		{
+			name: "ready",
+			conditions: []virtuslabv1alpha1.JenkinsCondition{
+				{Type: virtuslabv1alpha1.JenkinsReady, Status: corev1.ConditionTrue, Reason: ReasonCompleted},
+				{Type: virtuslabv1alpha1.JenkinsDegraded, Status: corev1.ConditionFalse, Reason: ReasonReconcileSucceeded},
+			},
+			expected: virtuslabv1alpha1.JenkinsRunningPhase,
+		},
+		{
+			name: "invalid user configuration",
+			conditions: []virtuslabv1alpha1.JenkinsCondition{
+				{Type: virtuslabv1alpha1.JenkinsBaseConfigured, Status: corev1.ConditionTrue, Reason: ReasonCompleted},
+				{Type: virtuslabv1alpha1.JenkinsUserConfigured, Status: corev1.ConditionFalse, Reason: ReasonValidationFailed},
+			},
+			expected: virtuslabv1alpha1.JenkinsFailedPhase,
+		},
+		{
+			name: "degraded",
+			conditions: []virtuslabv1alpha1.JenkinsCondition{
+				{Type: virtuslabv1alpha1.JenkinsReady, Status: corev1.ConditionFalse, Reason: ReasonReconcileFailed},
+				{Type: virtuslabv1alpha1.JenkinsDegraded, Status: corev1.ConditionTrue, Reason: ReasonReconcileFailed},
+			},
+			expected: virtuslabv1alpha1.JenkinsFailedPhase,
+		},
+	}
+
+	for _, testingData := range data {
+		t.Run(testingData.name, func(t *testing.T) {
+			status := virtuslabv1alpha1.JenkinsStatus{Conditions: testingData.conditions}
+
+			assert.Equal(t, testingData.expected, Phase(status))
+		})
+	}
+}
@@ -4,23 +4,28 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/backup"
 	jenkinsclient "github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/client"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/conditions"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/base/resources"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/groovy"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/plugins"
 	"github.com/VirtusLab/jenkins-operator/pkg/log"
+	"github.com/VirtusLab/jenkins-operator/pkg/metrics"
 
 	"github.com/bndr/gojenkins"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,6 +34,11 @@ import (
 
 const (
 	fetchAllPlugins = 1
+
+	// restartReasonMissingPlugins is the reason of Jenkins master pod restart when required plugins are not installed
+	restartReasonMissingPlugins = "missing_plugins"
+	// restartReasonPodChanged is the reason of Jenkins master pod restart when the pod doesn't match Jenkins CR
+	restartReasonPodChanged = "pod_changed"
 )
 
 // ReconcileJenkinsBaseConfiguration defines values required for Jenkins base configuration
@@ -62,16 +72,15 @@ func (r *ReconcileJenkinsBaseConfiguration) Reconcile() (reconcile.Result, jenki
 		return reconcile.Result{}, nil, err
 	}
 
-	pluginsRequiredByAllBackupProviders := backup.GetPluginsRequiredByAllBackupProviders()
-	result, err := r.ensurePluginsRequiredByAllBackupProviders(pluginsRequiredByAllBackupProviders)
-	if err != nil {
-		return reconcile.Result{}, nil, err
-	}
-	if result.Requeue {
-		return result, nil, nil
+	requiredPlugins := backup.GetPluginsRequiredByAllBackupProviders()
+	if r.jenkins.Spec.Monitoring.Enabled {
+		for key, value := range plugins.MonitoringPluginsMap {
+			requiredPlugins[key] = value
+		}
 	}
+	r.addRequiredPlugins(requiredPlugins)
 
-	result, err = r.ensureJenkinsMasterPod(metaObject)
+	result, err := r.ensureJenkinsMasterPod(metaObject)
 	if err != nil {
 		return reconcile.Result{}, nil, err
 	}
@@ -95,14 +104,17 @@ func (r *ReconcileJenkinsBaseConfiguration) Reconcile() (reconcile.Result, jenki
 	}
 	r.logger.V(log.VDebug).Info("Jenkins API client set")
 
-	ok, err := r.verifyPlugins(jenkinsClient, plugins.BasePluginsMap, pluginsRequiredByAllBackupProviders)
+	ok, err := r.verifyPlugins(jenkinsClient, plugins.BasePluginsMap, requiredPlugins)
 	if err != nil {
 		return reconcile.Result{}, nil, err
 	}
 	if !ok {
 		r.logger.V(log.VWarn).Info("Please correct Jenkins CR (spec.master.plugins)")
-		return reconcile.Result{Requeue: true}, nil, r.restartJenkinsMasterPod(metaObject)
+		conditions.Set(&r.jenkins.Status, virtuslabv1alpha1.JenkinsPluginsInSync, corev1.ConditionFalse, conditions.ReasonMissingPlugins,
+			"Required plugins are not installed, restarting Jenkins master pod")
+		return reconcile.Result{Requeue: true}, nil, r.restartJenkinsMasterPod(metaObject, restartReasonMissingPlugins)
 	}
+	conditions.Set(&r.jenkins.Status, virtuslabv1alpha1.JenkinsPluginsInSync, corev1.ConditionTrue, conditions.ReasonPluginsInstalled, "")
 
 	result, err = r.ensureBaseConfiguration(jenkinsClient)
 	return result, jenkinsClient, err
@@ -144,11 +156,20 @@ func (r *ReconcileJenkinsBaseConfiguration) ensureResourcesRequiredForJenkinsPod
 	}
 	r.logger.V(log.VDebug).Info("Service is present")
 
+	if err := r.createServiceMonitor(metaObject); err != nil {
+		return err
+	}
+
 	if err := r.createBackupCredentialsSecret(metaObject); err != nil {
 		return err
 	}
 	r.logger.V(log.VDebug).Info("Backup credentials secret is present")
 
+	if err := r.createManagedCredentialsSecret(metaObject); err != nil {
+		return err
+	}
+	r.logger.V(log.VDebug).Info("Managed credentials secret is present")
+
 	return nil
 }
 
@@ -166,24 +187,25 @@ func (r *ReconcileJenkinsBaseConfiguration) verifyPlugins(jenkinsClient jenkinsc
 	}
 	r.logger.V(log.VDebug).Info(fmt.Sprintf("Installed plugins '%+v'", installedPlugins))
 
-	status := true
+	missing := 0
 	for _, requiredPlugins := range allRequiredPlugins {
 		for rootPluginName, p := range requiredPlugins {
 			rootPlugin, _ := plugins.New(rootPluginName)
 			if found, ok := isPluginInstalled(allPluginsInJenkins, *rootPlugin); !ok {
 				r.logger.V(log.VWarn).Info(fmt.Sprintf("Missing plugin '%s', actual '%+v'", rootPlugin, found))
-				status = false
+				missing++
 			}
 			for _, requiredPlugin := range p {
 				if found, ok := isPluginInstalled(allPluginsInJenkins, requiredPlugin); !ok {
 					r.logger.V(log.VWarn).Info(fmt.Sprintf("Missing plugin '%s', actual '%+v'", requiredPlugin, found))
-					status = false
+					missing++
 				}
 			}
 		}
 	}
+	metrics.SetMissingPlugins(r.jenkins, missing)
 
-	return status, nil
+	return missing == 0, nil
 }
 
 func isPluginInstalled(plugins *gojenkins.Plugins, requiredPlugin plugins.Plugin) (gojenkins.Plugin, bool) {
@@ -213,6 +235,17 @@ func (r *ReconcileJenkinsBaseConfiguration) createOperatorCredentialsSecret(meta
 	return r.updateResource(resources.NewOperatorCredentialsSecret(meta, r.jenkins))
 }
 
+func (r *ReconcileJenkinsBaseConfiguration) createManagedCredentialsSecret(meta metav1.ObjectMeta) error {
+	found := &corev1.Secret{}
+	err := r.k8sClient.Get(context.TODO(), types.NamespacedName{Name: resources.GetManagedCredentialsSecretName(r.jenkins), Namespace: r.jenkins.ObjectMeta.Namespace}, found)
+	if err != nil && apierrors.IsNotFound(err) {
+		return r.createResource(resources.NewManagedCredentialsSecret(meta, r.jenkins))
+	}
+
+	// content of the secret is managed in the user configuration phase
+	return err
+}
+
 func (r *ReconcileJenkinsBaseConfiguration) createScriptsConfigMap(meta metav1.ObjectMeta) error {
 	configMap, err := resources.NewScriptsConfigMap(meta, r.jenkins)
 	if err != nil {
@@ -245,7 +278,7 @@ func (r *ReconcileJenkinsBaseConfiguration) createUserConfigurationConfigMap(met
 	} else if err != nil {
 		return err
 	}
-	valid := r.verifyLabelsForWatchedResource(currentConfigMap)
+	valid := resources.VerifyLabelsForWatchedResource(currentConfigMap, r.jenkins)
 	if !valid {
 		currentConfigMap.ObjectMeta.Labels = resources.BuildLabelsForWatchedResources(r.jenkins)
 		return r.k8sClient.Update(context.TODO(), currentConfigMap)
@@ -277,11 +310,69 @@ func (r *ReconcileJenkinsBaseConfiguration) createRBAC(meta metav1.ObjectMeta) e
 }
 
 func (r *ReconcileJenkinsBaseConfiguration) createService(meta metav1.ObjectMeta) error {
-	err := r.createResource(resources.NewService(meta, r.minikube))
-	if err != nil && !apierrors.IsAlreadyExists(err) {
+	service := resources.NewService(meta, r.jenkins, r.minikube)
+	currentService := &corev1.Service{}
+	err := r.k8sClient.Get(context.TODO(), types.NamespacedName{Name: service.Name, Namespace: service.Namespace}, currentService)
+	if err != nil && apierrors.IsNotFound(err) {
+		err = r.createResource(service)
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// only Prometheus annotations are updated, the rest of the service is set once
+	if resources.SetPrometheusAnnotations(currentService, r.jenkins.Spec.Monitoring.Enabled) {
+		return r.updateResource(currentService)
+	}
+
+	return nil
+}
+
+// createServiceMonitor creates or updates ServiceMonitor when monitoring is enabled and Prometheus Operator is installed,
+// the ServiceMonitor is deleted when monitoring is disabled or together with Jenkins CR
+func (r *ReconcileJenkinsBaseConfiguration) createServiceMonitor(meta metav1.ObjectMeta) error {
+	serviceMonitor := resources.NewServiceMonitor(meta, r.jenkins)
+	currentServiceMonitor := &unstructured.Unstructured{}
+	currentServiceMonitor.SetGroupVersionKind(resources.ServiceMonitorGroupVersionKind)
+	err := r.k8sClient.Get(context.TODO(), types.NamespacedName{Name: serviceMonitor.GetName(), Namespace: serviceMonitor.GetNamespace()}, currentServiceMonitor)
+	if err != nil && apimeta.IsNoMatchError(err) {
+		r.logger.V(log.VDebug).Info(fmt.Sprintf("Skipping ServiceMonitor, '%s' kind is not known in the cluster",
+			resources.ServiceMonitorGroupVersionKind))
+		return nil
+	} else if err != nil && apierrors.IsNotFound(err) {
+		if !r.jenkins.Spec.Monitoring.Enabled {
+			return nil
+		}
+		err = r.createResource(serviceMonitor)
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		r.logger.V(log.VDebug).Info("ServiceMonitor is present")
+		return nil
+	} else if err != nil {
 		return err
 	}
 
+	if !r.jenkins.Spec.Monitoring.Enabled {
+		err = r.k8sClient.Delete(context.TODO(), currentServiceMonitor)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		r.logger.Info("ServiceMonitor has been deleted, monitoring is disabled")
+		return nil
+	}
+
+	if !reflect.DeepEqual(currentServiceMonitor.GetLabels(), serviceMonitor.GetLabels()) {
+		currentServiceMonitor.SetLabels(serviceMonitor.GetLabels())
+		if err = r.updateResource(currentServiceMonitor); err != nil {
+			return err
+		}
+	}
+	r.logger.V(log.VDebug).Info("ServiceMonitor is present")
+
 	return nil
 }
 
@@ -344,20 +435,78 @@ func (r *ReconcileJenkinsBaseConfiguration) ensureJenkinsMasterPod(meta metav1.O
 		recreatePod = true
 	}
 
+	if currentJenkinsMasterPod != nil &&
+		!compareVolumes(resources.NewJenkinsMasterPod(meta, r.jenkins), currentJenkinsMasterPod) {
+		r.logger.Info("Jenkins pod volumes have changed, recreating pod")
+		recreatePod = true
+	}
+
 	if currentJenkinsMasterPod != nil && recreatePod && currentJenkinsMasterPod.ObjectMeta.DeletionTimestamp == nil {
-		return reconcile.Result{Requeue: true}, r.restartJenkinsMasterPod(meta)
+		return reconcile.Result{Requeue: true}, r.restartJenkinsMasterPod(meta, restartReasonPodChanged)
 	}
 
 	return reconcile.Result{}, nil
 }
 
-func (r *ReconcileJenkinsBaseConfiguration) restartJenkinsMasterPod(meta metav1.ObjectMeta) error {
+// compareVolumes checks if the volumes of the actual pod refer to the same Kubernetes resources as the expected pod,
+// only volumes created by the operator are compared, volumes added by Kubernetes or admission controllers are skipped
+func compareVolumes(expected, actual *corev1.Pod) bool {
+	actualVolumes := map[string]string{}
+	for _, volume := range actual.Spec.Volumes {
+		if !resources.IsJenkinsMasterPodVolume(volume.Name) {
+			continue
+		}
+		actualVolumes[volume.Name] = getVolumeSourceName(volume)
+	}
+
+	if len(expected.Spec.Volumes) != len(actualVolumes) {
+		return false
+	}
+	for _, volume := range expected.Spec.Volumes {
+		sourceName, found := actualVolumes[volume.Name]
+		if !found || sourceName != getVolumeSourceName(volume) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func getVolumeSourceName(volume corev1.Volume) string {
+	switch {
+	case volume.ConfigMap != nil:
+		return "configmap/" + volume.ConfigMap.Name
+	case volume.Secret != nil:
+		return "secret/" + volume.Secret.SecretName
+	case volume.Projected != nil:
+		var names []string
+		for _, source := range volume.Projected.Sources {
+			if source.ConfigMap != nil {
+				names = append(names, "configmap/"+source.ConfigMap.Name)
+			}
+			if source.Secret != nil {
+				names = append(names, "secret/"+source.Secret.Name)
+			}
+		}
+		return strings.Join(names, ",")
+	case volume.EmptyDir != nil:
+		return "emptydir"
+	default:
+		return ""
+	}
+}
+
+func (r *ReconcileJenkinsBaseConfiguration) restartJenkinsMasterPod(meta metav1.ObjectMeta, reason string) error {
 	currentJenkinsMasterPod, err := r.getJenkinsMasterPod(meta)
-	r.logger.Info(fmt.Sprintf("Terminating Jenkins Master Pod %s/%s", currentJenkinsMasterPod.Namespace, currentJenkinsMasterPod.Name))
 	if err != nil {
 		return err
 	}
-	return r.k8sClient.Delete(context.TODO(), currentJenkinsMasterPod)
+	r.logger.Info(fmt.Sprintf("Terminating Jenkins Master Pod %s/%s", currentJenkinsMasterPod.Namespace, currentJenkinsMasterPod.Name))
+	if err := r.k8sClient.Delete(context.TODO(), currentJenkinsMasterPod); err != nil {
+		return err
+	}
+	metrics.IncMasterPodRestarts(r.jenkins, reason)
+	return nil
 }
 
 func (r *ReconcileJenkinsBaseConfiguration) waitForJenkins(meta metav1.ObjectMeta) (reconcile.Result, error) {
@@ -386,6 +535,31 @@ func (r *ReconcileJenkinsBaseConfiguration) waitForJenkins(meta metav1.ObjectMet
 	return reconcile.Result{}, nil
 }
 
+// NewJenkinsClient returns Jenkins API client authenticated by the operator token,
+// it can be used by other controllers only after base configuration of the Jenkins instance is completed
+func NewJenkinsClient(k8sClient client.Client, jenkins *virtuslabv1alpha1.Jenkins, local, minikube bool) (jenkinsclient.Jenkins, error) {
+	if jenkins.Status.BaseConfigurationCompletedTime == nil {
+		return nil, fmt.Errorf("base configuration of Jenkins '%s' is not completed yet", jenkins.Name)
+	}
+
+	jenkinsURL, err := jenkinsclient.BuildJenkinsAPIUrl(
+		jenkins.ObjectMeta.Namespace, resources.GetResourceName(jenkins), resources.HTTPPortInt, local, minikube)
+	if err != nil {
+		return nil, err
+	}
+
+	credentialsSecret := &corev1.Secret{}
+	err = k8sClient.Get(context.TODO(), types.NamespacedName{Name: resources.GetOperatorCredentialsSecretName(jenkins), Namespace: jenkins.ObjectMeta.Namespace}, credentialsSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return jenkinsclient.New(
+		jenkinsURL,
+		string(credentialsSecret.Data[resources.OperatorCredentialsSecretUserNameKey]),
+		string(credentialsSecret.Data[resources.OperatorCredentialsSecretTokenKey]))
+}
+
 func (r *ReconcileJenkinsBaseConfiguration) ensureJenkinsClient(meta metav1.ObjectMeta) (jenkinsclient.Jenkins, error) {
 	jenkinsURL, err := jenkinsclient.BuildJenkinsAPIUrl(
 		r.jenkins.ObjectMeta.Namespace, meta.Name, resources.HTTPPortInt, r.local, r.minikube)
@@ -448,7 +622,7 @@ func (r *ReconcileJenkinsBaseConfiguration) ensureJenkinsClient(meta metav1.Obje
 }
 
 func (r *ReconcileJenkinsBaseConfiguration) ensureBaseConfiguration(jenkinsClient jenkinsclient.Jenkins) (reconcile.Result, error) {
-	groovyClient := groovy.New(jenkinsClient, r.k8sClient, r.logger, fmt.Sprintf("%s-base-configuration", constants.OperatorName), resources.JenkinsBaseConfigurationVolumePath)
+	groovyClient := groovy.New(jenkinsClient, r.k8sClient, r.logger, fmt.Sprintf("%s-base-configuration", constants.OperatorName), resources.JenkinsBaseConfigurationVolumePath, "")
 
 	err := groovyClient.ConfigureGroovyJob()
 	if err != nil {
@@ -462,7 +636,7 @@ func (r *ReconcileJenkinsBaseConfiguration) ensureBaseConfiguration(jenkinsClien
 		return reconcile.Result{}, err
 	}
 
-	done, err := groovyClient.EnsureGroovyJob(configuration.Data, r.jenkins)
+	done, err := groovyClient.EnsureGroovyJob(configuration.Data, nil, r.jenkins)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -481,7 +655,7 @@ func (r *ReconcileJenkinsBaseConfiguration) createBackupCredentialsSecret(meta m
 	} else if err != nil {
 		return err
 	}
-	valid := r.verifyLabelsForWatchedResource(currentSecret)
+	valid := resources.VerifyLabelsForWatchedResource(currentSecret, r.jenkins)
 	if !valid {
 		currentSecret.ObjectMeta.Labels = resources.BuildLabelsForWatchedResources(r.jenkins)
 		return r.k8sClient.Update(context.TODO(), currentSecret)
@@ -490,18 +664,9 @@ func (r *ReconcileJenkinsBaseConfiguration) createBackupCredentialsSecret(meta m
 	return nil
 }
 
-func (r *ReconcileJenkinsBaseConfiguration) verifyLabelsForWatchedResource(object metav1.Object) bool {
-	requiredLabels := resources.BuildLabelsForWatchedResources(r.jenkins)
-	for key, value := range requiredLabels {
-		if object.GetLabels()[key] != value {
-			return false
-		}
-	}
-
-	return true
-}
-
-func (r *ReconcileJenkinsBaseConfiguration) ensurePluginsRequiredByAllBackupProviders(requiredPlugins map[string][]plugins.Plugin) (reconcile.Result, error) {
+// addRequiredPlugins adds plugins required by backup providers and monitoring to Jenkins CR spec,
+// like other spec defaults they are not persisted
+func (r *ReconcileJenkinsBaseConfiguration) addRequiredPlugins(requiredPlugins map[string][]plugins.Plugin) {
 	copiedPlugins := map[string][]string{}
 	for key, value := range r.jenkins.Spec.Master.Plugins {
 		copiedPlugins[key] = value
@@ -517,11 +682,7 @@ func (r *ReconcileJenkinsBaseConfiguration) ensurePluginsRequiredByAllBackupProv
 	}
 
 	if !reflect.DeepEqual(r.jenkins.Spec.Master.Plugins, copiedPlugins) {
-		r.logger.Info("Adding plugins required by backup providers to '.spec.master.plugins'")
+		r.logger.V(log.VDebug).Info("Adding plugins required by backup providers and monitoring to '.spec.master.plugins'")
 		r.jenkins.Spec.Master.Plugins = copiedPlugins
-		err := r.k8sClient.Update(context.TODO(), r.jenkins)
-		return reconcile.Result{Requeue: true}, err
 	}
-
-	return reconcile.Result{}, nil
 }
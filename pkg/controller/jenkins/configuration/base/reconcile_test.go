@@ -5,22 +5,26 @@ import (
 	"testing"
 
 	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/base/resources"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/plugins"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 )
 
-func TestReconcileJenkinsBaseConfiguration_ensurePluginsRequiredByAllBackupProviders(t *testing.T) {
+func TestReconcileJenkinsBaseConfiguration_addRequiredPlugins(t *testing.T) {
 	tests := []struct {
 		name            string
 		jenkins         *virtuslabv1alpha1.Jenkins
 		requiredPlugins map[string][]plugins.Plugin
-		want            reconcile.Result
-		wantErr         bool
+		want            map[string][]string
 	}{
 		{
 			name: "happy, no required plugins",
@@ -33,8 +37,9 @@ func TestReconcileJenkinsBaseConfiguration_ensurePluginsRequiredByAllBackupProvi
 					},
 				},
 			},
-			want:    reconcile.Result{Requeue: false},
-			wantErr: false,
+			want: map[string][]string{
+				"first-plugin:0.0.1": {"second-plugin:0.0.1"},
+			},
 		},
 		{
 			name: "happy, required plugins are set",
@@ -50,11 +55,12 @@ func TestReconcileJenkinsBaseConfiguration_ensurePluginsRequiredByAllBackupProvi
 			requiredPlugins: map[string][]plugins.Plugin{
 				"first-plugin:0.0.1": {plugins.Must(plugins.New("second-plugin:0.0.1"))},
 			},
-			want:    reconcile.Result{Requeue: false},
-			wantErr: false,
+			want: map[string][]string{
+				"first-plugin:0.0.1": {"second-plugin:0.0.1"},
+			},
 		},
 		{
-			name: "happy, jenkins CR must be updated",
+			name: "happy, required plugins are added",
 			jenkins: &virtuslabv1alpha1.Jenkins{
 				Spec: virtuslabv1alpha1.JenkinsSpec{
 					Master: virtuslabv1alpha1.JenkinsMaster{
@@ -68,11 +74,13 @@ func TestReconcileJenkinsBaseConfiguration_ensurePluginsRequiredByAllBackupProvi
 				"first-plugin:0.0.1": {plugins.Must(plugins.New("second-plugin:0.0.1"))},
 				"third-plugin:0.0.1": {},
 			},
-			want:    reconcile.Result{Requeue: true},
-			wantErr: false,
+			want: map[string][]string{
+				"first-plugin:0.0.1": {"second-plugin:0.0.1"},
+				"third-plugin:0.0.1": nil,
+			},
 		},
 		{
-			name: "happy, jenkins CR must be updated",
+			name: "happy, required plugins with dependencies are added",
 			jenkins: &virtuslabv1alpha1.Jenkins{
 				Spec: virtuslabv1alpha1.JenkinsSpec{
 					Master: virtuslabv1alpha1.JenkinsMaster{
@@ -86,14 +94,14 @@ func TestReconcileJenkinsBaseConfiguration_ensurePluginsRequiredByAllBackupProvi
 				"first-plugin:0.0.1": {plugins.Must(plugins.New("second-plugin:0.0.1"))},
 				"third-plugin:0.0.1": {plugins.Must(plugins.New("fourth-plugin:0.0.1"))},
 			},
-			want:    reconcile.Result{Requeue: true},
-			wantErr: false,
+			want: map[string][]string{
+				"first-plugin:0.0.1": {"second-plugin:0.0.1"},
+				"third-plugin:0.0.1": {"fourth-plugin:0.0.1"},
+			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := virtuslabv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme)
-			assert.NoError(t, err)
 			r := &ReconcileJenkinsBaseConfiguration{
 				k8sClient: fake.NewFakeClient(),
 				scheme:    nil,
@@ -102,15 +110,191 @@ func TestReconcileJenkinsBaseConfiguration_ensurePluginsRequiredByAllBackupProvi
 				local:     false,
 				minikube:  false,
 			}
-			err = r.k8sClient.Create(context.TODO(), tt.jenkins)
-			assert.NoError(t, err)
-			got, err := r.ensurePluginsRequiredByAllBackupProviders(tt.requiredPlugins)
-			if tt.wantErr {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-			}
-			assert.Equal(t, tt.want, got)
+			r.addRequiredPlugins(tt.requiredPlugins)
+			assert.Equal(t, tt.want, tt.jenkins.Spec.Master.Plugins)
 		})
 	}
 }
+
+func TestCompareVolumes(t *testing.T) {
+	jenkins := &virtuslabv1alpha1.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jenkins",
+			Namespace: "default",
+		},
+	}
+	meta := resources.NewResourceObjectMeta(jenkins)
+	t.Run("the same volumes", func(t *testing.T) {
+		expected := resources.NewJenkinsMasterPod(meta, jenkins)
+		actual := resources.NewJenkinsMasterPod(meta, jenkins)
+
+		assert.True(t, compareVolumes(expected, actual))
+	})
+	t.Run("service account token volume is skipped", func(t *testing.T) {
+		expected := resources.NewJenkinsMasterPod(meta, jenkins)
+		actual := resources.NewJenkinsMasterPod(meta, jenkins)
+		actual.Spec.Volumes = append(actual.Spec.Volumes, corev1.Volume{
+			Name: "token",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: actual.Spec.ServiceAccountName + "-token-abcde",
+				},
+			},
+		})
+
+		assert.True(t, compareVolumes(expected, actual))
+	})
+	t.Run("injected projected volume is skipped", func(t *testing.T) {
+		expected := resources.NewJenkinsMasterPod(meta, jenkins)
+		actual := resources.NewJenkinsMasterPod(meta, jenkins)
+		actual.Spec.Volumes = append(actual.Spec.Volumes, corev1.Volume{
+			Name: "kube-api-access-x7k2p",
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{
+						{ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "token"}},
+						{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "kube-root-ca.crt"}}},
+					},
+				},
+			},
+		}, corev1.Volume{
+			Name:         "istio-envoy",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+
+		assert.True(t, compareVolumes(expected, actual))
+	})
+	t.Run("user configuration source removed", func(t *testing.T) {
+		actualJenkins := jenkins.DeepCopy()
+		actualJenkins.Spec.UserConfiguration.Sources = []virtuslabv1alpha1.UserConfigurationSource{
+			{ConfigMapRef: &virtuslabv1alpha1.ConfigMapRef{Name: "overrides"}},
+		}
+		actual := resources.NewJenkinsMasterPod(meta, actualJenkins)
+		expected := resources.NewJenkinsMasterPod(meta, jenkins)
+
+		assert.False(t, compareVolumes(expected, actual))
+	})
+	t.Run("configuration as code config map added", func(t *testing.T) {
+		actual := resources.NewJenkinsMasterPod(meta, jenkins)
+		changedJenkins := jenkins.DeepCopy()
+		changedJenkins.Spec.ConfigurationAsCode.Configurations = []virtuslabv1alpha1.ConfigMapRef{{Name: "casc"}}
+		expected := resources.NewJenkinsMasterPod(meta, changedJenkins)
+
+		assert.False(t, compareVolumes(expected, actual))
+	})
+	t.Run("configuration as code config map changed", func(t *testing.T) {
+		actualJenkins := jenkins.DeepCopy()
+		actualJenkins.Spec.ConfigurationAsCode.Configurations = []virtuslabv1alpha1.ConfigMapRef{{Name: "casc"}}
+		actual := resources.NewJenkinsMasterPod(meta, actualJenkins)
+		expectedJenkins := jenkins.DeepCopy()
+		expectedJenkins.Spec.ConfigurationAsCode.Configurations = []virtuslabv1alpha1.ConfigMapRef{{Name: "casc"}, {Name: "casc-2"}}
+		expected := resources.NewJenkinsMasterPod(meta, expectedJenkins)
+
+		assert.False(t, compareVolumes(expected, actual))
+	})
+}
+
+func TestReconcileJenkinsBaseConfiguration_createService(t *testing.T) {
+	err := virtuslabv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+	jenkins := &virtuslabv1alpha1.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jenkins",
+			Namespace: "default",
+		},
+	}
+	meta := resources.NewResourceObjectMeta(jenkins)
+	r := New(fake.NewFakeClient(), scheme.Scheme, logf.ZapLogger(false), jenkins, false, false)
+
+	err = r.createService(meta)
+	assert.NoError(t, err)
+	service := &corev1.Service{}
+	err = r.k8sClient.Get(context.TODO(), types.NamespacedName{Name: meta.Name, Namespace: meta.Namespace}, service)
+	assert.NoError(t, err)
+	assert.Empty(t, service.Annotations)
+
+	t.Run("monitoring enabled", func(t *testing.T) {
+		service.Annotations = map[string]string{"user-annotation": "value"}
+		err = r.k8sClient.Update(context.TODO(), service)
+		assert.NoError(t, err)
+		jenkins.Spec.Monitoring.Enabled = true
+
+		err = r.createService(meta)
+		assert.NoError(t, err)
+
+		err = r.k8sClient.Get(context.TODO(), types.NamespacedName{Name: meta.Name, Namespace: meta.Namespace}, service)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"user-annotation":      "value",
+			"prometheus.io/scrape": "true",
+			"prometheus.io/path":   resources.PrometheusPath,
+			"prometheus.io/port":   "8080",
+		}, service.Annotations)
+	})
+	t.Run("monitoring disabled", func(t *testing.T) {
+		jenkins.Spec.Monitoring.Enabled = false
+
+		err = r.createService(meta)
+		assert.NoError(t, err)
+
+		service = &corev1.Service{}
+		err = r.k8sClient.Get(context.TODO(), types.NamespacedName{Name: meta.Name, Namespace: meta.Namespace}, service)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"user-annotation": "value"}, service.Annotations)
+	})
+}
+
+func TestReconcileJenkinsBaseConfiguration_createServiceMonitor(t *testing.T) {
+	err := virtuslabv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+	jenkins := &virtuslabv1alpha1.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jenkins",
+			Namespace: "default",
+		},
+	}
+	meta := resources.NewResourceObjectMeta(jenkins)
+	r := New(fake.NewFakeClient(), scheme.Scheme, logf.ZapLogger(false), jenkins, false, false)
+	getServiceMonitor := func() (*unstructured.Unstructured, error) {
+		serviceMonitor := &unstructured.Unstructured{}
+		serviceMonitor.SetGroupVersionKind(resources.ServiceMonitorGroupVersionKind)
+		err := r.k8sClient.Get(context.TODO(), types.NamespacedName{Name: meta.Name, Namespace: meta.Namespace}, serviceMonitor)
+		return serviceMonitor, err
+	}
+
+	err = r.createServiceMonitor(meta)
+	assert.NoError(t, err)
+	_, err = getServiceMonitor()
+	assert.True(t, apierrors.IsNotFound(err))
+
+	t.Run("monitoring enabled", func(t *testing.T) {
+		jenkins.Spec.Monitoring.Enabled = true
+
+		err = r.createServiceMonitor(meta)
+		assert.NoError(t, err)
+
+		serviceMonitor, err := getServiceMonitor()
+		assert.NoError(t, err)
+		assert.Equal(t, meta.Labels, serviceMonitor.GetLabels())
+	})
+	t.Run("service monitor labels changed", func(t *testing.T) {
+		jenkins.Spec.Monitoring.ServiceMonitorLabels = map[string]string{"release": "prometheus"}
+
+		err = r.createServiceMonitor(meta)
+		assert.NoError(t, err)
+
+		serviceMonitor, err := getServiceMonitor()
+		assert.NoError(t, err)
+		assert.Equal(t, "prometheus", serviceMonitor.GetLabels()["release"])
+		assert.Len(t, serviceMonitor.GetLabels(), len(meta.Labels)+1)
+	})
+	t.Run("monitoring disabled", func(t *testing.T) {
+		jenkins.Spec.Monitoring.Enabled = false
+
+		err = r.createServiceMonitor(meta)
+		assert.NoError(t, err)
+
+		_, err = getServiceMonitor()
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+}
@@ -2,6 +2,8 @@ package resources
 
 import (
 	"fmt"
+	"strings"
+	"text/template"
 
 	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
@@ -101,13 +103,24 @@ println("CLI disabled")
 jenkins.save()
 `
 
-const configureKubernetesPluginFmt = `
+const configureKubernetesPluginFileName = "6-configure-kubernetes-plugin.groovy"
+
+// configureKubernetesPluginTemplate configures Kubernetes cloud with pod templates from Jenkins CR,
+// the cloud is replaced on every run so changes in Jenkins CR are applied
+var configureKubernetesPluginTemplate = template.Must(template.New(configureKubernetesPluginFileName).
+	Funcs(template.FuncMap{"groovy": groovyString, "join": strings.Join, "resource": resourceQuantity}).Parse(`
 import com.cloudbees.plugins.credentials.CredentialsScope
 import com.cloudbees.plugins.credentials.SystemCredentialsProvider
 import com.cloudbees.plugins.credentials.domains.Domain
 import jenkins.model.Jenkins
+import org.csanchez.jenkins.plugins.kubernetes.ContainerTemplate
 import org.csanchez.jenkins.plugins.kubernetes.KubernetesCloud
+import org.csanchez.jenkins.plugins.kubernetes.PodTemplate
 import org.csanchez.jenkins.plugins.kubernetes.ServiceAccountCredential
+import org.csanchez.jenkins.plugins.kubernetes.volumes.ConfigMapVolume
+import org.csanchez.jenkins.plugins.kubernetes.volumes.EmptyDirVolume
+import org.csanchez.jenkins.plugins.kubernetes.volumes.PersistentVolumeClaim
+import org.csanchez.jenkins.plugins.kubernetes.volumes.SecretVolume
 
 def kubernetesCredentialsId = 'kubernetes-namespace-token'
 def jenkins = Jenkins.getInstance()
@@ -119,22 +132,108 @@ ServiceAccountCredential serviceAccountCredential = new ServiceAccountCredential
 )
 SystemCredentialsProvider.getInstance().getStore().addCredentials(Domain.global(), serviceAccountCredential)
 
+def podTemplates = []
+def podTemplate
+def containers
+def container
+def volumes
+{{- range .PodTemplates }}
+
+podTemplate = new PodTemplate()
+podTemplate.setName({{ groovy .Name }})
+podTemplate.setLabel({{ groovy (join .Labels " ") }})
+podTemplate.setNamespace({{ groovy $.Namespace }})
+podTemplate.setServiceAccount({{ groovy .ServiceAccountName }})
+podTemplate.setIdleMinutes({{ .IdleMinutes }})
+containers = []
+{{- range .Containers }}
+container = new ContainerTemplate({{ groovy .Name }}, {{ groovy .Image }})
+container.setCommand({{ groovy .Command }})
+container.setArgs({{ groovy .Args }})
+{{- if .WorkingDir }}
+container.setWorkingDir({{ groovy .WorkingDir }})
+{{- end }}
+container.setTtyEnabled(true)
+container.setResourceRequestCpu({{ groovy (resource .Resources.Requests "cpu") }})
+container.setResourceRequestMemory({{ groovy (resource .Resources.Requests "memory") }})
+container.setResourceLimitCpu({{ groovy (resource .Resources.Limits "cpu") }})
+container.setResourceLimitMemory({{ groovy (resource .Resources.Limits "memory") }})
+containers.add(container)
+{{- end }}
+podTemplate.setContainers(containers)
+volumes = []
+{{- range .Volumes }}
+{{- if .ConfigMapName }}
+volumes.add(new ConfigMapVolume({{ groovy .MountPath }}, {{ groovy .ConfigMapName }}))
+{{- else if .SecretName }}
+volumes.add(new SecretVolume({{ groovy .MountPath }}, {{ groovy .SecretName }}))
+{{- else if .PersistentVolumeClaimName }}
+volumes.add(new PersistentVolumeClaim({{ groovy .MountPath }}, {{ groovy .PersistentVolumeClaimName }}, {{ .ReadOnly }}))
+{{- else }}
+volumes.add(new EmptyDirVolume({{ groovy .MountPath }}, false))
+{{- end }}
+{{- end }}
+podTemplate.setVolumes(volumes)
+podTemplates.add(podTemplate)
+{{- end }}
+
 KubernetesCloud kubernetes = new KubernetesCloud("kubernetes")
 kubernetes.setServerUrl("https://kubernetes.default")
-kubernetes.setNamespace("%s")
+kubernetes.setNamespace({{ groovy .Namespace }})
 kubernetes.setCredentialsId(kubernetesCredentialsId)
-kubernetes.setJenkinsUrl("http://%s:%d")
+kubernetes.setJenkinsUrl({{ groovy .JenkinsURL }})
+kubernetes.setJenkinsTunnel({{ groovy .JenkinsTunnel }})
 kubernetes.setRetentionTimeout(15)
+kubernetes.setTemplates(podTemplates)
+
+def existingKubernetes = jenkins.clouds.getByName("kubernetes")
+if (existingKubernetes != null) {
+    jenkins.clouds.remove(existingKubernetes)
+}
 jenkins.clouds.add(kubernetes)
 
 jenkins.save()
-`
+`))
+
+// resourceQuantity returns quantity of the resource or empty string when it's not set
+func resourceQuantity(resources corev1.ResourceList, name string) string {
+	if quantity, ok := resources[corev1.ResourceName(name)]; ok {
+		return quantity.String()
+	}
+	return ""
+}
 
-const configureViews = `
+func buildConfigureKubernetesPluginGroovyScript(jenkins *virtuslabv1alpha1.Jenkins) (string, error) {
+	data := struct {
+		Namespace     string
+		JenkinsURL    string
+		JenkinsTunnel string
+		PodTemplates  []virtuslabv1alpha1.PodTemplate
+	}{
+		Namespace:     jenkins.ObjectMeta.Namespace,
+		JenkinsURL:    fmt.Sprintf("http://%s:%d", GetResourceName(jenkins), HTTPPortInt),
+		JenkinsTunnel: fmt.Sprintf("%s:%d", GetResourceName(jenkins), slavePortInt),
+		PodTemplates:  jenkins.Spec.Agents.PodTemplates,
+	}
+	return render(configureKubernetesPluginTemplate, data)
+}
+
+const configureViewsFileName = "7-configure-views.groovy"
+
+// configureViewsTemplate creates default views and views from Jenkins CR, names of views created from Jenkins CR
+// are kept in a file in Jenkins home so they can be deleted when removed from Jenkins CR
+var configureViewsTemplate = template.Must(template.New(configureViewsFileName).
+	Funcs(template.FuncMap{"groovy": groovyString}).Parse(`{{ define "views" }}[
+{{- range . }}
+    [name: {{ groovy .Name }}, type: {{ groovy (printf "%s" .Type) }}, description: {{ groovy .Description }}, ` +
+	`includeRegex: {{ groovy .IncludeRegex }}, jobs: [{{ range .Jobs }}{{ groovy . }}, {{ end }}], recurse: {{ .Recurse }}, ` +
+	`views: {{ template "views" .Views }}],
+{{- end }}
+]{{ end }}
 import hudson.model.ListView
 import jenkins.model.Jenkins
 
-def Jenkins jenkins = Jenkins.getInstance()
+def jenkins = Jenkins.getInstance()
 
 def seedViewName = 'seed-jobs'
 def nonSeedViewName = 'non-seed-jobs'
@@ -158,8 +257,124 @@ if (jenkins.getView(jenkinsViewName) == null) {
     jenkins.addView(jenkinsView)
 }
 
+def configureListView(owner, spec) {
+    def view = owner.getView(spec.name)
+    if (view != null && !(view instanceof ListView)) {
+        owner.deleteView(view)
+        view = null
+    }
+    if (view == null) {
+        view = new ListView(spec.name, owner)
+        owner.addView(view)
+    }
+    view.setDescription(spec.description)
+    view.setIncludeRegex(spec.includeRegex)
+    view.setRecurse(spec.recurse)
+    synchronized (view) {
+        view.@jobNames.clear()
+        view.@jobNames.addAll(spec.jobs)
+    }
+}
+
+def configureNestedView(owner, spec) {
+    def nestedViewClass = Jenkins.getInstance().pluginManager.uberClassLoader.loadClass('hudson.plugins.nested_view.NestedView')
+    def view = owner.getView(spec.name)
+    if (view != null && !nestedViewClass.isInstance(view)) {
+        owner.deleteView(view)
+        view = null
+    }
+    if (view == null) {
+        view = nestedViewClass.newInstance(spec.name)
+        view.@owner = owner
+        owner.addView(view)
+    }
+    view.setDescription(spec.description)
+
+    def viewNames = spec.views.collect { it.name }
+    new ArrayList(view.getViews()).findAll { !viewNames.contains(it.getViewName()) }.each { view.deleteView(it) }
+    spec.views.each { configureView(view, it) }
+}
+
+def configureView(owner, spec) {
+    if (spec.type == '` + string(virtuslabv1alpha1.NestedViewType) + `') {
+        configureNestedView(owner, spec)
+    } else {
+        configureListView(owner, spec)
+    }
+}
+
+def views = {{ template "views" .Views }}
+def viewNames = views.collect { it.name }
+def managedFile = new File(jenkins.getRootDir(), '` + constants.OperatorName + `-views.txt')
+if (managedFile.exists()) {
+    managedFile.readLines().findAll { !viewNames.contains(it) }.each { name ->
+        def view = jenkins.getView(name)
+        if (view != null) {
+            jenkins.deleteView(view)
+        }
+    }
+}
+views.each { configureView(jenkins, it) }
+managedFile.text = viewNames.join('\n')
+
 jenkins.save()
-`
+`))
+
+func buildConfigureViewsGroovyScript(jenkins *virtuslabv1alpha1.Jenkins) (string, error) {
+	return render(configureViewsTemplate, jenkins.Spec)
+}
+
+const configureScriptApprovalFileName = "8-configure-script-approval.groovy"
+
+// configureScriptApprovalTemplate approves scripts and signatures from Jenkins CR, entries approved by the operator
+// are kept in a file in Jenkins home so they can be revoked when removed from Jenkins CR
+var configureScriptApprovalTemplate = template.Must(template.New(configureScriptApprovalFileName).
+	Funcs(template.FuncMap{"groovy": groovyString}).Parse(`
+import jenkins.model.Jenkins
+import org.jenkinsci.plugins.scriptsecurity.scripts.ScriptApproval
+
+def scriptApproval = ScriptApproval.get()
+def managedFile = new File(Jenkins.getInstance().getRootDir(), '` + constants.OperatorName + `-script-approval.txt')
+
+def scriptHashes = [
+{{- range .ApprovedScriptHashes }}
+    {{ groovy . }},
+{{- end }}
+] as Set
+def signatures = [
+{{- range .ApprovedSignatures }}
+    {{ groovy . }},
+{{- end }}
+] as Set
+
+if (managedFile.exists()) {
+    managedFile.readLines().each { line ->
+        if (line.startsWith('script:') && !scriptHashes.contains(line.substring('script:'.length()))) {
+            synchronized (scriptApproval) {
+                scriptApproval.@approvedScriptHashes.remove(line.substring('script:'.length()))
+            }
+        } else if (line.startsWith('signature:') && !signatures.contains(line.substring('signature:'.length()))) {
+            scriptApproval.denyApprovedSignature(line.substring('signature:'.length()))
+        }
+    }
+}
+
+scriptHashes.each { hash -> scriptApproval.approveScript(hash) }
+signatures.each { signature -> scriptApproval.approveSignature(signature) }
+scriptApproval.save()
+
+managedFile.text = (scriptHashes.collect { "script:${it}" } + signatures.collect { "signature:${it}" }).join('\n')
+`))
+
+// groovyString returns value as Groovy single-quoted string literal
+func groovyString(value string) string {
+	replacer := strings.NewReplacer("\\", "\\\\", "'", "\\'", "\n", "\\n", "\r", "\\r")
+	return "'" + replacer.Replace(value) + "'"
+}
+
+func buildConfigureScriptApprovalGroovyScript(jenkins *virtuslabv1alpha1.Jenkins) (string, error) {
+	return render(configureScriptApprovalTemplate, jenkins.Spec.ScriptApproval)
+}
 
 // GetBaseConfigurationConfigMapName returns name of Kubernetes config map used to base configuration
 func GetBaseConfigurationConfigMapName(jenkins *virtuslabv1alpha1.Jenkins) string {
@@ -170,6 +385,21 @@ func GetBaseConfigurationConfigMapName(jenkins *virtuslabv1alpha1.Jenkins) strin
 func NewBaseConfigurationConfigMap(meta metav1.ObjectMeta, jenkins *virtuslabv1alpha1.Jenkins) (*corev1.ConfigMap, error) {
 	meta.Name = GetBaseConfigurationConfigMapName(jenkins)
 
+	configureKubernetesPlugin, err := buildConfigureKubernetesPluginGroovyScript(jenkins)
+	if err != nil {
+		return nil, err
+	}
+
+	configureViews, err := buildConfigureViewsGroovyScript(jenkins)
+	if err != nil {
+		return nil, err
+	}
+
+	configureScriptApproval, err := buildConfigureScriptApprovalGroovyScript(jenkins)
+	if err != nil {
+		return nil, err
+	}
+
 	return &corev1.ConfigMap{
 		TypeMeta:   buildConfigMapTypeMeta(),
 		ObjectMeta: meta,
@@ -179,9 +409,9 @@ func NewBaseConfigurationConfigMap(meta metav1.ObjectMeta, jenkins *virtuslabv1a
 			"3-disable-usage-stats.groovy":          disableUsageStats,
 			"4-enable-master-access-control.groovy": enableMasterAccessControl,
 			"5-disable-insecure-features.groovy":    disableInsecureFeatures,
-			"6-configure-kubernetes-plugin.groovy": fmt.Sprintf(configureKubernetesPluginFmt,
-				jenkins.ObjectMeta.Namespace, GetResourceName(jenkins), HTTPPortInt),
-			"7-configure-views.groovy": configureViews,
+			configureKubernetesPluginFileName:       configureKubernetesPlugin,
+			configureViewsFileName:                  configureViews,
+			configureScriptApprovalFileName:         configureScriptApproval,
 		},
 	}, nil
 }
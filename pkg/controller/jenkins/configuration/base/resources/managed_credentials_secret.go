@@ -0,0 +1,41 @@
+package resources
+
+import (
+	"fmt"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ManagedCredentialsSecretIndexKey defines key of JSON list of all credentials managed by operator
+	// in managed credentials secret
+	ManagedCredentialsSecretIndexKey = "credentials.json"
+)
+
+// GetManagedCredentialsSecretName returns name of Kubernetes secret used to store all credentials
+// from Jenkins CR which are synchronized to Jenkins
+func GetManagedCredentialsSecretName(jenkins *virtuslabv1alpha1.Jenkins) string {
+	return fmt.Sprintf("%s-managed-credentials-%s", constants.OperatorName, jenkins.Name)
+}
+
+// GetManagedCredentialsSecretKey returns key in managed credentials secret which contains given secret key of credential
+func GetManagedCredentialsSecretKey(credentialID, key string) string {
+	return fmt.Sprintf("%s.%s", credentialID, key)
+}
+
+// NewManagedCredentialsSecret builds the Kubernetes secret used to store all credentials from Jenkins CR,
+// the secret is filled in the user configuration phase
+func NewManagedCredentialsSecret(meta metav1.ObjectMeta, jenkins *virtuslabv1alpha1.Jenkins) *corev1.Secret {
+	meta.Name = GetManagedCredentialsSecretName(jenkins)
+	return &corev1.Secret{
+		TypeMeta:   buildSecretTypeMeta(),
+		ObjectMeta: meta,
+		Data: map[string][]byte{
+			ManagedCredentialsSecretIndexKey: []byte("[]"),
+		},
+	}
+}
@@ -37,6 +37,37 @@ func BuildLabelsForWatchedResources(jenkins *virtuslabv1alpha1.Jenkins) map[stri
 	}
 }
 
+// VerifyLabelsForWatchedResource checks if Kubernetes resource has all labels required to be watched by operator
+func VerifyLabelsForWatchedResource(object metav1.Object, jenkins *virtuslabv1alpha1.Jenkins) bool {
+	requiredLabels := BuildLabelsForWatchedResources(jenkins)
+	for key, value := range requiredLabels {
+		if object.GetLabels()[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AddLabelsForWatchedResource adds labels required by operator to watch Kubernetes resource provided by user,
+// other labels are preserved, returns true if labels have been changed
+func AddLabelsForWatchedResource(object metav1.Object, jenkins *virtuslabv1alpha1.Jenkins) bool {
+	if VerifyLabelsForWatchedResource(object, jenkins) {
+		return false
+	}
+
+	labels := object.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for key, value := range BuildLabelsForWatchedResources(jenkins) {
+		labels[key] = value
+	}
+	object.SetLabels(labels)
+
+	return true
+}
+
 // GetResourceName returns name of Kubernetes resource base on Jenkins CR
 func GetResourceName(jenkins *virtuslabv1alpha1.Jenkins) string {
 	return fmt.Sprintf("%s-%s", constants.LabelAppValue, jenkins.ObjectMeta.Name)
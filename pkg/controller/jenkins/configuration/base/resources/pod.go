@@ -39,6 +39,31 @@ const (
 	// credentials are provided by user
 	JenkinsBackupCredentialsVolumePath = "/var/jenkins/backup-credentials"
 
+	jenkinsUserConfigurationSourceVolumeNameFmt = "user-configuration-source-%d"
+	// JenkinsUserConfigurationSourcesVolumePath is a path where are mounted additional sources of groovy scripts
+	// used to configure Jenkins, this scripts are provided by user
+	JenkinsUserConfigurationSourcesVolumePath = "/var/jenkins/user-configuration-sources"
+
+	jenkinsManagedCredentialsVolumeName = "managed-credentials"
+	// JenkinsManagedCredentialsVolumePath is a path where are credentials from Jenkins CR synchronized to Jenkins
+	// credentials store
+	JenkinsManagedCredentialsVolumePath = "/var/jenkins/managed-credentials"
+
+	jenkinsUserConfigurationSecretVolumeNameFmt = "user-configuration-secret-%d"
+	// JenkinsUserConfigurationSecretsVolumePath is a path where are mounted secrets available in groovy scripts
+	// used to configure Jenkins, every secret is mounted in a separate directory named like the secret
+	JenkinsUserConfigurationSecretsVolumePath = "/var/jenkins/user-configuration-secrets"
+
+	jenkinsConfigurationAsCodeVolumeName = "configuration-as-code"
+	// JenkinsConfigurationAsCodeVolumePath is a path where are YAML files used by Configuration as Code Jenkins plugin
+	// this files are provided by user
+	JenkinsConfigurationAsCodeVolumePath = "/var/jenkins/configuration-as-code"
+
+	jenkinsConfigurationAsCodeSecretsVolumeName = "configuration-as-code-secrets"
+	// JenkinsConfigurationAsCodeSecretsVolumePath is a path where are secrets used by Configuration as Code Jenkins plugin
+	// secrets are provided by user
+	JenkinsConfigurationAsCodeSecretsVolumePath = "/var/jenkins/configuration-as-code-secrets"
+
 	httpPortName  = "http"
 	slavePortName = "slavelistener"
 	// HTTPPortInt defines Jenkins master HTTP port
@@ -57,6 +82,263 @@ func buildPodTypeMeta() metav1.TypeMeta {
 	}
 }
 
+func getJenkinsMasterContainerEnvs(jenkins *virtuslabv1alpha1.Jenkins) []corev1.EnvVar {
+	envs := []corev1.EnvVar{
+		{
+			Name:  "JENKINS_HOME",
+			Value: jenkinsHomePath,
+		},
+		{
+			Name:  "JAVA_OPTS",
+			Value: "-XX:+UnlockExperimentalVMOptions -XX:+UseCGroupMemoryLimitForHeap -XX:MaxRAMFraction=1 -Djenkins.install.runSetupWizard=false -Djava.awt.headless=true",
+		},
+	}
+
+	if len(jenkins.Spec.ConfigurationAsCode.Secret.Name) > 0 {
+		// Configuration as Code plugin resolves ${VARIABLE} references using files from this directory
+		envs = append(envs, corev1.EnvVar{
+			Name:  "SECRETS",
+			Value: JenkinsConfigurationAsCodeSecretsVolumePath,
+		})
+	}
+
+	return envs
+}
+
+func getJenkinsMasterContainerVolumeMounts(jenkins *virtuslabv1alpha1.Jenkins) []corev1.VolumeMount {
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      jenkinsHomeVolumeName,
+			MountPath: jenkinsHomePath,
+			ReadOnly:  false,
+		},
+		{
+			Name:      jenkinsScriptsVolumeName,
+			MountPath: jenkinsScriptsVolumePath,
+			ReadOnly:  true,
+		},
+		{
+			Name:      jenkinsInitConfigurationVolumeName,
+			MountPath: jenkinsInitConfigurationVolumePath,
+			ReadOnly:  true,
+		},
+		{
+			Name:      jenkinsBaseConfigurationVolumeName,
+			MountPath: JenkinsBaseConfigurationVolumePath,
+			ReadOnly:  true,
+		},
+		{
+			Name:      jenkinsUserConfigurationVolumeName,
+			MountPath: JenkinsUserConfigurationVolumePath,
+			ReadOnly:  true,
+		},
+		{
+			Name:      jenkinsOperatorCredentialsVolumeName,
+			MountPath: jenkinsOperatorCredentialsVolumePath,
+			ReadOnly:  true,
+		},
+		{
+			Name:      jenkinsBackupCredentialsVolumeName,
+			MountPath: JenkinsBackupCredentialsVolumePath,
+			ReadOnly:  true,
+		},
+		{
+			Name:      jenkinsManagedCredentialsVolumeName,
+			MountPath: JenkinsManagedCredentialsVolumePath,
+			ReadOnly:  true,
+		},
+	}
+
+	for index, source := range jenkins.Spec.UserConfiguration.Sources {
+		if len(GetUserConfigurationSourceName(source)) == 0 {
+			continue
+		}
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      fmt.Sprintf(jenkinsUserConfigurationSourceVolumeNameFmt, index),
+			MountPath: GetUserConfigurationSourceVolumePath(source),
+			ReadOnly:  true,
+		})
+	}
+	for index, secret := range jenkins.Spec.UserConfiguration.Secrets {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      fmt.Sprintf(jenkinsUserConfigurationSecretVolumeNameFmt, index),
+			MountPath: fmt.Sprintf("%s/%s", JenkinsUserConfigurationSecretsVolumePath, secret.Name),
+			ReadOnly:  true,
+		})
+	}
+	if len(jenkins.Spec.ConfigurationAsCode.Configurations) > 0 {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      jenkinsConfigurationAsCodeVolumeName,
+			MountPath: JenkinsConfigurationAsCodeVolumePath,
+			ReadOnly:  true,
+		})
+	}
+	if len(jenkins.Spec.ConfigurationAsCode.Secret.Name) > 0 {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      jenkinsConfigurationAsCodeSecretsVolumeName,
+			MountPath: JenkinsConfigurationAsCodeSecretsVolumePath,
+			ReadOnly:  true,
+		})
+	}
+
+	return volumeMounts
+}
+
+func getJenkinsMasterPodVolumes(jenkins *virtuslabv1alpha1.Jenkins) []corev1.Volume {
+	volumes := []corev1.Volume{
+		{
+			Name: jenkinsHomeVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		{
+			Name: jenkinsScriptsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: getScriptsConfigMapName(jenkins),
+					},
+				},
+			},
+		},
+		{
+			Name: jenkinsInitConfigurationVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: GetInitConfigurationConfigMapName(jenkins),
+					},
+				},
+			},
+		},
+		{
+			Name: jenkinsBaseConfigurationVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: GetBaseConfigurationConfigMapName(jenkins),
+					},
+				},
+			},
+		},
+		{
+			Name: jenkinsUserConfigurationVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: GetUserConfigurationConfigMapName(jenkins),
+					},
+				},
+			},
+		},
+		{
+			Name: jenkinsOperatorCredentialsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: GetOperatorCredentialsSecretName(jenkins),
+				},
+			},
+		},
+		{
+			Name: jenkinsBackupCredentialsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: GetBackupCredentialsSecretName(jenkins),
+				},
+			},
+		},
+		{
+			Name: jenkinsManagedCredentialsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: GetManagedCredentialsSecretName(jenkins),
+				},
+			},
+		},
+	}
+
+	for index, source := range jenkins.Spec.UserConfiguration.Sources {
+		volume := corev1.Volume{
+			Name: fmt.Sprintf(jenkinsUserConfigurationSourceVolumeNameFmt, index),
+		}
+		if source.SecretRef != nil {
+			volume.VolumeSource.Secret = &corev1.SecretVolumeSource{
+				SecretName: source.SecretRef.Name,
+			}
+		} else if source.ConfigMapRef != nil {
+			volume.VolumeSource.ConfigMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: source.ConfigMapRef.Name,
+				},
+			}
+		} else {
+			continue
+		}
+		volumes = append(volumes, volume)
+	}
+	for index, secret := range jenkins.Spec.UserConfiguration.Secrets {
+		volumes = append(volumes, corev1.Volume{
+			Name: fmt.Sprintf(jenkinsUserConfigurationSecretVolumeNameFmt, index),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secret.Name,
+				},
+			},
+		})
+	}
+	if len(jenkins.Spec.ConfigurationAsCode.Configurations) > 0 {
+		var sources []corev1.VolumeProjection
+		for _, configMap := range jenkins.Spec.ConfigurationAsCode.Configurations {
+			sources = append(sources, corev1.VolumeProjection{
+				ConfigMap: &corev1.ConfigMapProjection{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: configMap.Name,
+					},
+				},
+			})
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name: jenkinsConfigurationAsCodeVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: sources,
+				},
+			},
+		})
+	}
+	if len(jenkins.Spec.ConfigurationAsCode.Secret.Name) > 0 {
+		volumes = append(volumes, corev1.Volume{
+			Name: jenkinsConfigurationAsCodeSecretsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: jenkins.Spec.ConfigurationAsCode.Secret.Name,
+				},
+			},
+		})
+	}
+
+	return volumes
+}
+
+// IsJenkinsMasterPodVolume checks if the volume is created by the operator for Jenkins master pod,
+// other volumes are added by Kubernetes or admission controllers (like service account token)
+func IsJenkinsMasterPodVolume(volumeName string) bool {
+	switch volumeName {
+	case jenkinsHomeVolumeName, jenkinsScriptsVolumeName, jenkinsInitConfigurationVolumeName, jenkinsBaseConfigurationVolumeName,
+		jenkinsUserConfigurationVolumeName, jenkinsOperatorCredentialsVolumeName, jenkinsBackupCredentialsVolumeName,
+		jenkinsManagedCredentialsVolumeName, jenkinsConfigurationAsCodeVolumeName, jenkinsConfigurationAsCodeSecretsVolumeName:
+		return true
+	}
+	for _, nameFmt := range []string{jenkinsUserConfigurationSourceVolumeNameFmt, jenkinsUserConfigurationSecretVolumeNameFmt} {
+		var index int
+		if _, err := fmt.Sscanf(volumeName, nameFmt, &index); err == nil && fmt.Sprintf(nameFmt, index) == volumeName {
+			return true
+		}
+	}
+	return false
+}
+
 // NewJenkinsMasterPod builds Jenkins Master Kubernetes Pod resource
 func NewJenkinsMasterPod(objectMeta metav1.ObjectMeta, jenkins *virtuslabv1alpha1.Jenkins) *corev1.Pod {
 	initialDelaySeconds := int32(30)
@@ -126,120 +408,12 @@ func NewJenkinsMasterPod(objectMeta metav1.ObjectMeta, jenkins *virtuslabv1alpha
 							ContainerPort: httpPortInt32,
 						},
 					},
-					Env: []corev1.EnvVar{
-						{
-							Name:  "JENKINS_HOME",
-							Value: jenkinsHomePath,
-						},
-						{
-							Name:  "JAVA_OPTS",
-							Value: "-XX:+UnlockExperimentalVMOptions -XX:+UseCGroupMemoryLimitForHeap -XX:MaxRAMFraction=1 -Djenkins.install.runSetupWizard=false -Djava.awt.headless=true",
-						},
-					},
-					Resources: jenkins.Spec.Master.Resources,
-					VolumeMounts: []corev1.VolumeMount{
-						{
-							Name:      jenkinsHomeVolumeName,
-							MountPath: jenkinsHomePath,
-							ReadOnly:  false,
-						},
-						{
-							Name:      jenkinsScriptsVolumeName,
-							MountPath: jenkinsScriptsVolumePath,
-							ReadOnly:  true,
-						},
-						{
-							Name:      jenkinsInitConfigurationVolumeName,
-							MountPath: jenkinsInitConfigurationVolumePath,
-							ReadOnly:  true,
-						},
-						{
-							Name:      jenkinsBaseConfigurationVolumeName,
-							MountPath: JenkinsBaseConfigurationVolumePath,
-							ReadOnly:  true,
-						},
-						{
-							Name:      jenkinsUserConfigurationVolumeName,
-							MountPath: JenkinsUserConfigurationVolumePath,
-							ReadOnly:  true,
-						},
-						{
-							Name:      jenkinsOperatorCredentialsVolumeName,
-							MountPath: jenkinsOperatorCredentialsVolumePath,
-							ReadOnly:  true,
-						},
-						{
-							Name:      jenkinsBackupCredentialsVolumeName,
-							MountPath: JenkinsBackupCredentialsVolumePath,
-							ReadOnly:  true,
-						},
-					},
-				},
-			},
-			Volumes: []corev1.Volume{
-				{
-					Name: jenkinsHomeVolumeName,
-					VolumeSource: corev1.VolumeSource{
-						EmptyDir: &corev1.EmptyDirVolumeSource{},
-					},
-				},
-				{
-					Name: jenkinsScriptsVolumeName,
-					VolumeSource: corev1.VolumeSource{
-						ConfigMap: &corev1.ConfigMapVolumeSource{
-							LocalObjectReference: corev1.LocalObjectReference{
-								Name: getScriptsConfigMapName(jenkins),
-							},
-						},
-					},
-				},
-				{
-					Name: jenkinsInitConfigurationVolumeName,
-					VolumeSource: corev1.VolumeSource{
-						ConfigMap: &corev1.ConfigMapVolumeSource{
-							LocalObjectReference: corev1.LocalObjectReference{
-								Name: GetInitConfigurationConfigMapName(jenkins),
-							},
-						},
-					},
-				},
-				{
-					Name: jenkinsBaseConfigurationVolumeName,
-					VolumeSource: corev1.VolumeSource{
-						ConfigMap: &corev1.ConfigMapVolumeSource{
-							LocalObjectReference: corev1.LocalObjectReference{
-								Name: GetBaseConfigurationConfigMapName(jenkins),
-							},
-						},
-					},
-				},
-				{
-					Name: jenkinsUserConfigurationVolumeName,
-					VolumeSource: corev1.VolumeSource{
-						ConfigMap: &corev1.ConfigMapVolumeSource{
-							LocalObjectReference: corev1.LocalObjectReference{
-								Name: GetUserConfigurationConfigMapName(jenkins),
-							},
-						},
-					},
-				},
-				{
-					Name: jenkinsOperatorCredentialsVolumeName,
-					VolumeSource: corev1.VolumeSource{
-						Secret: &corev1.SecretVolumeSource{
-							SecretName: GetOperatorCredentialsSecretName(jenkins),
-						},
-					},
-				},
-				{
-					Name: jenkinsBackupCredentialsVolumeName,
-					VolumeSource: corev1.VolumeSource{
-						Secret: &corev1.SecretVolumeSource{
-							SecretName: GetBackupCredentialsSecretName(jenkins),
-						},
-					},
+					Env:          getJenkinsMasterContainerEnvs(jenkins),
+					Resources:    jenkins.Spec.Master.Resources,
+					VolumeMounts: getJenkinsMasterContainerVolumeMounts(jenkins),
 				},
 			},
+			Volumes: getJenkinsMasterPodVolumes(jenkins),
 		},
 	}
 }
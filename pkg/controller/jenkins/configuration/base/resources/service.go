@@ -1,11 +1,24 @@
 package resources
 
 import (
+	"fmt"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+const (
+	// PrometheusPath is a path of Jenkins metrics endpoint exposed by prometheus plugin
+	PrometheusPath = "/prometheus/"
+
+	prometheusScrapeAnnotation = "prometheus.io/scrape"
+	prometheusPathAnnotation   = "prometheus.io/path"
+	prometheusPortAnnotation   = "prometheus.io/port"
+)
+
 func buildServiceTypeMeta() metav1.TypeMeta {
 	return metav1.TypeMeta{
 		Kind:       "Service",
@@ -14,7 +27,7 @@ func buildServiceTypeMeta() metav1.TypeMeta {
 }
 
 // NewService builds the Kubernetes service resource
-func NewService(meta metav1.ObjectMeta, minikube bool) *corev1.Service {
+func NewService(meta metav1.ObjectMeta, jenkins *virtuslabv1alpha1.Jenkins, minikube bool) *corev1.Service {
 	service := &corev1.Service{
 		TypeMeta:   buildServiceTypeMeta(),
 		ObjectMeta: meta,
@@ -45,5 +58,34 @@ func NewService(meta metav1.ObjectMeta, minikube bool) *corev1.Service {
 		service.Spec.Type = corev1.ServiceTypeClusterIP
 	}
 
+	SetPrometheusAnnotations(service, jenkins.Spec.Monitoring.Enabled)
+
 	return service
 }
+
+// SetPrometheusAnnotations adds annotations used by Prometheus to discover Jenkins metrics endpoint when monitoring
+// is enabled or removes them otherwise, other annotations are preserved, returns true if annotations have been changed
+func SetPrometheusAnnotations(service *corev1.Service, enabled bool) bool {
+	prometheusAnnotations := map[string]string{
+		prometheusScrapeAnnotation: "true",
+		prometheusPathAnnotation:   PrometheusPath,
+		prometheusPortAnnotation:   fmt.Sprintf("%d", HTTPPortInt),
+	}
+
+	changed := false
+	for key, value := range prometheusAnnotations {
+		current, found := service.Annotations[key]
+		if enabled && current != value {
+			if service.Annotations == nil {
+				service.Annotations = map[string]string{}
+			}
+			service.Annotations[key] = value
+			changed = true
+		} else if !enabled && found {
+			delete(service.Annotations, key)
+			changed = true
+		}
+	}
+
+	return changed
+}
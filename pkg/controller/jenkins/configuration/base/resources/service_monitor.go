@@ -0,0 +1,58 @@
+package resources
+
+import (
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ServiceMonitorGroupVersionKind is the kind of Prometheus Operator resource which defines how to scrape services
+var ServiceMonitorGroupVersionKind = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "ServiceMonitor",
+}
+
+// NewServiceMonitor builds Prometheus Operator ServiceMonitor which scrapes Jenkins metrics exposed by prometheus plugin,
+// the object is unstructured so the operator doesn't depend on Prometheus Operator API
+func NewServiceMonitor(meta metav1.ObjectMeta, jenkins *virtuslabv1alpha1.Jenkins) *unstructured.Unstructured {
+	labels := map[string]string{}
+	for key, value := range meta.Labels {
+		labels[key] = value
+	}
+	for key, value := range jenkins.Spec.Monitoring.ServiceMonitorLabels {
+		labels[key] = value
+	}
+
+	serviceLabels := map[string]interface{}{}
+	for key, value := range meta.Labels {
+		serviceLabels[key] = value
+	}
+
+	serviceMonitor := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"selector": map[string]interface{}{
+					"matchLabels": serviceLabels,
+				},
+				"namespaceSelector": map[string]interface{}{
+					"matchNames": []interface{}{meta.Namespace},
+				},
+				"endpoints": []interface{}{
+					map[string]interface{}{
+						"port": httpPortName,
+						"path": PrometheusPath,
+					},
+				},
+			},
+		},
+	}
+	serviceMonitor.SetGroupVersionKind(ServiceMonitorGroupVersionKind)
+	serviceMonitor.SetName(meta.Name)
+	serviceMonitor.SetNamespace(meta.Namespace)
+	serviceMonitor.SetLabels(labels)
+
+	return serviceMonitor
+}
@@ -0,0 +1,46 @@
+package resources
+
+import (
+	"testing"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewServiceMonitor(t *testing.T) {
+	jenkins := &virtuslabv1alpha1.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jenkins",
+			Namespace: "default",
+		},
+		Spec: virtuslabv1alpha1.JenkinsSpec{
+			Monitoring: virtuslabv1alpha1.JenkinsMonitoring{
+				Enabled:              true,
+				ServiceMonitorLabels: map[string]string{"release": "prometheus"},
+			},
+		},
+	}
+	meta := NewResourceObjectMeta(jenkins)
+
+	serviceMonitor := NewServiceMonitor(meta, jenkins)
+
+	assert.Equal(t, ServiceMonitorGroupVersionKind, serviceMonitor.GroupVersionKind())
+	assert.Equal(t, meta.Name, serviceMonitor.GetName())
+	assert.Equal(t, meta.Namespace, serviceMonitor.GetNamespace())
+	expectedLabels := BuildResourceLabels(jenkins)
+	expectedLabels["release"] = "prometheus"
+	assert.Equal(t, expectedLabels, serviceMonitor.GetLabels())
+
+	spec := serviceMonitor.Object["spec"].(map[string]interface{})
+	matchLabels := spec["selector"].(map[string]interface{})["matchLabels"].(map[string]interface{})
+	assert.Len(t, matchLabels, len(meta.Labels))
+	for key, value := range meta.Labels {
+		assert.Equal(t, value, matchLabels[key])
+	}
+	assert.Equal(t, []interface{}{"default"}, spec["namespaceSelector"].(map[string]interface{})["matchNames"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"port": httpPortName, "path": PrometheusPath},
+	}, spec["endpoints"])
+}
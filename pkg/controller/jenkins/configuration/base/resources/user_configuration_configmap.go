@@ -53,3 +53,19 @@ func NewUserConfigurationConfigMap(jenkins *virtuslabv1alpha1.Jenkins) *corev1.C
 		},
 	}
 }
+
+// GetUserConfigurationSourceName returns unique name of user configuration source
+func GetUserConfigurationSourceName(source virtuslabv1alpha1.UserConfigurationSource) string {
+	if source.SecretRef != nil {
+		return fmt.Sprintf("secret-%s", source.SecretRef.Name)
+	}
+	if source.ConfigMapRef != nil {
+		return fmt.Sprintf("configmap-%s", source.ConfigMapRef.Name)
+	}
+	return ""
+}
+
+// GetUserConfigurationSourceVolumePath returns path where groovy scripts of user configuration source are mounted
+func GetUserConfigurationSourceVolumePath(source virtuslabv1alpha1.UserConfigurationSource) string {
+	return fmt.Sprintf("%s/%s", JenkinsUserConfigurationSourcesVolumePath, GetUserConfigurationSourceName(source))
+}
@@ -8,68 +8,73 @@ import (
 	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/backup"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/base/resources"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/plugins"
-	"github.com/VirtusLab/jenkins-operator/pkg/log"
 
 	docker "github.com/docker/distribution/reference"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+const nestedViewPluginName = "nested-view"
+
 var (
 	dockerImageRegexp = regexp.MustCompile(`^` + docker.TagRegexp.String() + `$`)
+	// reservedViewNames contains names of views created by the operator
+	reservedViewNames = map[string]bool{"all": true, "seed-jobs": true, "non-seed-jobs": true, constants.OperatorName: true}
 )
 
-// Validate validates Jenkins CR Spec.master section
-func (r *ReconcileJenkinsBaseConfiguration) Validate(jenkins *virtuslabv1alpha1.Jenkins) (bool, error) {
+// Validate validates Jenkins CR Spec.master section, it returns errors of invalid fields
+func (r *ReconcileJenkinsBaseConfiguration) Validate(jenkins *virtuslabv1alpha1.Jenkins) (field.ErrorList, error) {
+	specPath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+
+	imagePath := specPath.Child("master", "image")
 	if jenkins.Spec.Master.Image == "" {
-		r.logger.V(log.VWarn).Info("Image not set")
-		return false, nil
+		allErrs = append(allErrs, field.Required(imagePath, "image not set"))
+	} else if !dockerImageRegexp.MatchString(jenkins.Spec.Master.Image) && !docker.ReferenceRegexp.MatchString(jenkins.Spec.Master.Image) {
+		allErrs = append(allErrs, field.Invalid(imagePath, jenkins.Spec.Master.Image, "invalid image"))
 	}
 
-	if !dockerImageRegexp.MatchString(jenkins.Spec.Master.Image) && !docker.ReferenceRegexp.MatchString(jenkins.Spec.Master.Image) {
-		r.logger.V(log.VWarn).Info("Invalid image")
-		return false, nil
-
-	}
+	allErrs = append(allErrs, validatePlugins(jenkins.Spec.Master.Plugins, specPath.Child("master", "plugins"))...)
+	allErrs = append(allErrs, validateAgents(jenkins.Spec.Agents, specPath.Child("agents"))...)
+	allErrs = append(allErrs, validateViews(jenkins.Spec.Views, jenkins.Spec.Master.Plugins, specPath.Child("views"))...)
 
-	if !r.validatePlugins(jenkins.Spec.Master.Plugins) {
-		return false, nil
+	backupErrs, err := r.verifyBackup()
+	if err != nil {
+		return nil, err
 	}
-
-	valid, err := r.verifyBackup()
-	if !valid || err != nil {
-		return valid, err
+	allErrs = append(allErrs, backupErrs...)
+	if len(backupErrs) > 0 {
+		return allErrs, nil
 	}
 
 	backupProvider, err := backup.GetBackupProvider(r.jenkins.Spec.Backup)
 	if err != nil {
-		return false, err
-	}
-
-	if !backupProvider.IsConfigurationValidForBasePhase(*r.jenkins, r.logger) {
-		return false, nil
+		return nil, err
 	}
+	allErrs = append(allErrs, backupProvider.ValidateForBasePhase(*r.jenkins)...)
 
-	return true, nil
+	return allErrs, nil
 }
 
-func (r *ReconcileJenkinsBaseConfiguration) validatePlugins(pluginsWithVersions map[string][]string) bool {
-	valid := true
+func validatePlugins(pluginsWithVersions map[string][]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
 	allPlugins := map[string][]plugins.Plugin{}
 
 	for rootPluginName, dependentPluginNames := range pluginsWithVersions {
+		rootPluginPath := fldPath.Key(rootPluginName)
 		if _, err := plugins.New(rootPluginName); err != nil {
-			r.logger.V(log.VWarn).Info(fmt.Sprintf("Invalid root plugin name '%s'", rootPluginName))
-			valid = false
+			allErrs = append(allErrs, field.Invalid(rootPluginPath, rootPluginName, err.Error()))
 		}
 
 		dependentPlugins := []plugins.Plugin{}
-		for _, pluginName := range dependentPluginNames {
+		for index, pluginName := range dependentPluginNames {
 			if p, err := plugins.New(pluginName); err != nil {
-				r.logger.V(log.VWarn).Info(fmt.Sprintf("Invalid dependent plugin name '%s' in root plugin '%s'", pluginName, rootPluginName))
-				valid = false
+				allErrs = append(allErrs, field.Invalid(rootPluginPath.Index(index), pluginName, err.Error()))
 			} else {
 				dependentPlugins = append(dependentPlugins, *p)
 			}
@@ -78,45 +83,175 @@ func (r *ReconcileJenkinsBaseConfiguration) validatePlugins(pluginsWithVersions
 		allPlugins[rootPluginName] = dependentPlugins
 	}
 
-	if valid {
-		return plugins.VerifyDependencies(allPlugins)
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	for _, conflict := range plugins.FindDependencyConflicts(allPlugins) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, conflict))
+	}
+
+	return allErrs
+}
+
+func validateAgents(agents virtuslabv1alpha1.JenkinsAgents, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	podTemplateNames := map[string]bool{}
+	for index, podTemplate := range agents.PodTemplates {
+		podTemplatePath := fldPath.Child("podTemplates").Index(index)
+		if len(podTemplate.Name) == 0 {
+			allErrs = append(allErrs, field.Required(podTemplatePath.Child("name"), "pod template name can't be empty"))
+			continue
+		}
+		if podTemplateNames[podTemplate.Name] {
+			allErrs = append(allErrs, field.Duplicate(podTemplatePath.Child("name"), podTemplate.Name))
+		}
+		podTemplateNames[podTemplate.Name] = true
+
+		if podTemplate.IdleMinutes < 0 {
+			allErrs = append(allErrs, field.Invalid(podTemplatePath.Child("idleMinutes"), podTemplate.IdleMinutes, "idle minutes can't be negative"))
+		}
+
+		if len(podTemplate.Containers) == 0 {
+			allErrs = append(allErrs, field.Required(podTemplatePath.Child("containers"), "pod template must have at least one container"))
+		}
+		for containerIndex, container := range podTemplate.Containers {
+			containerPath := podTemplatePath.Child("containers").Index(containerIndex)
+			for _, msg := range validation.IsDNS1123Label(container.Name) {
+				allErrs = append(allErrs, field.Invalid(containerPath.Child("name"), container.Name, msg))
+			}
+			if len(container.Image) == 0 {
+				allErrs = append(allErrs, field.Required(containerPath.Child("image"), "container image can't be empty"))
+			}
+		}
+
+		for volumeIndex, volume := range podTemplate.Volumes {
+			volumePath := podTemplatePath.Child("volumes").Index(volumeIndex)
+			if len(volume.MountPath) == 0 {
+				allErrs = append(allErrs, field.Required(volumePath.Child("mountPath"), "volume mount path can't be empty"))
+			}
+			sources := 0
+			for _, source := range []string{volume.ConfigMapName, volume.SecretName, volume.PersistentVolumeClaimName} {
+				if len(source) > 0 {
+					sources++
+				}
+			}
+			if sources > 1 {
+				allErrs = append(allErrs, field.Forbidden(volumePath,
+					"volume can have only one of configMapName, secretName and persistentVolumeClaimName"))
+			}
+		}
 	}
 
-	return valid
+	return allErrs
 }
 
-func (r *ReconcileJenkinsBaseConfiguration) verifyBackup() (bool, error) {
+func validateViews(views []virtuslabv1alpha1.View, pluginsWithVersions map[string][]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for index, view := range views {
+		if reservedViewNames[view.Name] {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(index).Child("name"), view.Name, "view name is reserved by the operator"))
+		}
+	}
+
+	return append(allErrs, validateNestedViews(views, pluginsWithVersions, fldPath)...)
+}
+
+func validateNestedViews(views []virtuslabv1alpha1.View, pluginsWithVersions map[string][]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	viewNames := map[string]bool{}
+	for index, view := range views {
+		viewPath := fldPath.Index(index)
+		if len(view.Name) == 0 {
+			allErrs = append(allErrs, field.Required(viewPath.Child("name"), "view name can't be empty"))
+			continue
+		}
+		if viewNames[view.Name] {
+			allErrs = append(allErrs, field.Duplicate(viewPath.Child("name"), view.Name))
+		}
+		viewNames[view.Name] = true
+
+		switch view.Type {
+		case "", virtuslabv1alpha1.ListViewType:
+			if len(view.Views) > 0 {
+				allErrs = append(allErrs, field.Forbidden(viewPath.Child("views"), "list view can't contain views"))
+			}
+			if _, err := regexp.Compile(view.IncludeRegex); err != nil {
+				allErrs = append(allErrs, field.Invalid(viewPath.Child("includeRegex"), view.IncludeRegex, err.Error()))
+			}
+		case virtuslabv1alpha1.NestedViewType:
+			if !plugins.IsConfigured(pluginsWithVersions, nestedViewPluginName) {
+				allErrs = append(allErrs, field.Invalid(viewPath.Child("type"), view.Type,
+					fmt.Sprintf("nested view requires '%s' plugin in spec.master.plugins", nestedViewPluginName)))
+			}
+			if len(view.Jobs) > 0 || len(view.IncludeRegex) > 0 || view.Recurse {
+				allErrs = append(allErrs, field.Forbidden(viewPath, "nested view can contain only views"))
+			}
+			allErrs = append(allErrs, validateNestedViews(view.Views, pluginsWithVersions, viewPath.Child("views"))...)
+		default:
+			allErrs = append(allErrs, field.NotSupported(viewPath.Child("type"), view.Type, viewTypes()))
+		}
+	}
+
+	return allErrs
+}
+
+func viewTypes() []string {
+	var allowed []string
+	for _, viewType := range virtuslabv1alpha1.AllowedViewTypes {
+		allowed = append(allowed, string(viewType))
+	}
+	return allowed
+}
+
+func (r *ReconcileJenkinsBaseConfiguration) verifyBackup() (field.ErrorList, error) {
+	backupPath := field.NewPath("spec", "backup")
 	if r.jenkins.Spec.Backup == "" {
-		r.logger.V(log.VWarn).Info("Backup strategy not set in 'spec.backup'")
-		return false, nil
+		return field.ErrorList{field.Required(backupPath, "backup strategy not set")}, nil
 	}
 
 	valid := false
+	var backupTypes []string
 	for _, backupType := range virtuslabv1alpha1.AllowedJenkinsBackups {
 		if r.jenkins.Spec.Backup == backupType {
 			valid = true
 		}
+		backupTypes = append(backupTypes, string(backupType))
 	}
 
 	if !valid {
-		r.logger.V(log.VWarn).Info(fmt.Sprintf("Invalid backup strategy '%s'", r.jenkins.Spec.Backup))
-		r.logger.V(log.VWarn).Info(fmt.Sprintf("Allowed backups '%+v'", virtuslabv1alpha1.AllowedJenkinsBackups))
-		return false, nil
+		return field.ErrorList{field.NotSupported(backupPath, r.jenkins.Spec.Backup, backupTypes)}, nil
+	}
+
+	if len(r.jenkins.Spec.BackupDeletionPolicy) > 0 && !isBackupDeletionPolicyAllowed(r.jenkins.Spec.BackupDeletionPolicy) {
+		var policies []string
+		for _, policy := range virtuslabv1alpha1.AllowedBackupDeletionPolicies {
+			policies = append(policies, string(policy))
+		}
+		return field.ErrorList{field.NotSupported(field.NewPath("spec", "backupDeletionPolicy"), r.jenkins.Spec.BackupDeletionPolicy, policies)}, nil
 	}
 
 	if r.jenkins.Spec.Backup == virtuslabv1alpha1.JenkinsBackupTypeNoBackup {
-		return true, nil
+		return nil, nil
 	}
 
 	backupSecretName := resources.GetBackupCredentialsSecretName(r.jenkins)
 	backupSecret := &corev1.Secret{}
 	err := r.k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: r.jenkins.Namespace, Name: backupSecretName}, backupSecret)
 	if err != nil && errors.IsNotFound(err) {
-		r.logger.V(log.VWarn).Info(fmt.Sprintf("Please create secret '%s' in namespace '%s'", backupSecretName, r.jenkins.Namespace))
-		return false, nil
+		return field.ErrorList{field.NotFound(backupPath, fmt.Sprintf("secret %s/%s", r.jenkins.Namespace, backupSecretName))}, nil
 	} else if err != nil && !errors.IsNotFound(err) {
-		return false, err
+		return nil, err
 	}
 
-	return true, nil
+	return nil, nil
+}
+
+func isBackupDeletionPolicyAllowed(policy virtuslabv1alpha1.BackupDeletionPolicy) bool {
+	for _, allowedPolicy := range virtuslabv1alpha1.AllowedBackupDeletionPolicies {
+		if allowedPolicy == policy {
+			return true
+		}
+	}
+	return false
 }
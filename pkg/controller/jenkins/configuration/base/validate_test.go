@@ -10,10 +10,75 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 )
 
+func TestValidate(t *testing.T) {
+	data := []struct {
+		name           string
+		image          string
+		backup         virtuslabv1alpha1.JenkinsBackup
+		deletionPolicy virtuslabv1alpha1.BackupDeletionPolicy
+		expectedPaths  []string
+	}{
+		{
+			name:   "happy",
+			image:  "jenkins/jenkins:lts",
+			backup: virtuslabv1alpha1.JenkinsBackupTypeNoBackup,
+		},
+		{
+			name:          "fail, image not set",
+			image:         "",
+			backup:        virtuslabv1alpha1.JenkinsBackupTypeNoBackup,
+			expectedPaths: []string{"spec.master.image"},
+		},
+		{
+			name:          "fail, invalid image",
+			image:         "jenkins/jenkins:lts:latest",
+			backup:        virtuslabv1alpha1.JenkinsBackupTypeNoBackup,
+			expectedPaths: []string{"spec.master.image"},
+		},
+		{
+			name:           "fail, invalid backup deletion policy",
+			image:          "jenkins/jenkins:lts",
+			backup:         virtuslabv1alpha1.JenkinsBackupTypeNoBackup,
+			deletionPolicy: "Orphan",
+			expectedPaths:  []string{"spec.backupDeletionPolicy"},
+		},
+		{
+			name:          "fail, all errors are reported",
+			image:         "",
+			backup:        "",
+			expectedPaths: []string{"spec.master.image", "spec.backup"},
+		},
+	}
+
+	for _, testingData := range data {
+		t.Run(testingData.name, func(t *testing.T) {
+			jenkins := &virtuslabv1alpha1.Jenkins{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "namespace-name", Name: "jenkins-cr-name"},
+				Spec: virtuslabv1alpha1.JenkinsSpec{
+					Master:               virtuslabv1alpha1.JenkinsMaster{Image: testingData.image},
+					Backup:               testingData.backup,
+					BackupDeletionPolicy: testingData.deletionPolicy,
+				},
+			}
+			baseReconcileLoop := New(fake.NewFakeClient(), nil, logf.ZapLogger(false), jenkins, false, false)
+
+			errs, err := baseReconcileLoop.Validate(jenkins)
+
+			assert.NoError(t, err)
+			var paths []string
+			for _, e := range errs {
+				paths = append(paths, e.Field)
+			}
+			assert.Equal(t, testingData.expectedPaths, paths)
+		})
+	}
+}
+
 func TestValidatePlugins(t *testing.T) {
 	data := []struct {
 		plugins        map[string][]string
@@ -50,15 +115,194 @@ func TestValidatePlugins(t *testing.T) {
 			},
 			expectedResult: true,
 		},
+		{
+			plugins: map[string][]string{
+				"valid-plugin-name:1.0-beta-1": {
+					"valid_plugin.name:3.2.1+1a2b3c",
+				},
+			},
+			expectedResult: true,
+		},
+		{
+			plugins: map[string][]string{
+				"valid-plugin-name:": {},
+			},
+			expectedResult: false,
+		},
+		{
+			plugins: map[string][]string{
+				":1.0": {},
+			},
+			expectedResult: false,
+		},
+		{
+			plugins: map[string][]string{
+				"valid-plugin-name:1.0": {
+					"invalid plugin name:1.0",
+				},
+			},
+			expectedResult: false,
+		},
+		{
+			plugins: map[string][]string{
+				"valid-plugin-name:1.0": {
+					"valid-plugin-name2:1.0:2.0",
+				},
+			},
+			expectedResult: false,
+		},
 	}
 
-	baseReconcileLoop := New(nil, nil, logf.ZapLogger(false),
-		nil, false, false)
-
 	for index, testingData := range data {
 		t.Run(fmt.Sprintf("Testing %d plugins set", index), func(t *testing.T) {
-			result := baseReconcileLoop.validatePlugins(testingData.plugins)
-			assert.Equal(t, testingData.expectedResult, result)
+			errs := validatePlugins(testingData.plugins, field.NewPath("spec", "master", "plugins"))
+			assert.Equal(t, testingData.expectedResult, len(errs) == 0, errs)
+		})
+	}
+}
+
+func TestValidateAgents(t *testing.T) {
+	data := []struct {
+		name           string
+		agents         virtuslabv1alpha1.JenkinsAgents
+		expectedResult bool
+	}{
+		{
+			name:           "no pod templates",
+			agents:         virtuslabv1alpha1.JenkinsAgents{},
+			expectedResult: true,
+		},
+		{
+			name: "valid pod template",
+			agents: virtuslabv1alpha1.JenkinsAgents{
+				PodTemplates: []virtuslabv1alpha1.PodTemplate{
+					{
+						Name:       "maven",
+						Labels:     []string{"maven"},
+						Containers: []virtuslabv1alpha1.ContainerTemplate{{Name: "maven", Image: "maven:3-jdk-8"}},
+						Volumes: []virtuslabv1alpha1.PodTemplateVolume{
+							{MountPath: "/root/.m2", PersistentVolumeClaimName: "maven-repository"},
+							{MountPath: "/tmp"},
+						},
+						IdleMinutes: 5,
+					},
+				},
+			},
+			expectedResult: true,
+		},
+		{
+			name: "duplicated pod template name",
+			agents: virtuslabv1alpha1.JenkinsAgents{
+				PodTemplates: []virtuslabv1alpha1.PodTemplate{
+					{Name: "maven", Containers: []virtuslabv1alpha1.ContainerTemplate{{Name: "maven", Image: "maven"}}},
+					{Name: "maven", Containers: []virtuslabv1alpha1.ContainerTemplate{{Name: "maven", Image: "maven"}}},
+				},
+			},
+			expectedResult: false,
+		},
+		{
+			name: "pod template without containers",
+			agents: virtuslabv1alpha1.JenkinsAgents{
+				PodTemplates: []virtuslabv1alpha1.PodTemplate{{Name: "maven"}},
+			},
+			expectedResult: false,
+		},
+		{
+			name: "invalid container name",
+			agents: virtuslabv1alpha1.JenkinsAgents{
+				PodTemplates: []virtuslabv1alpha1.PodTemplate{
+					{Name: "maven", Containers: []virtuslabv1alpha1.ContainerTemplate{{Name: "Maven_3", Image: "maven"}}},
+				},
+			},
+			expectedResult: false,
+		},
+		{
+			name: "volume with more than one source",
+			agents: virtuslabv1alpha1.JenkinsAgents{
+				PodTemplates: []virtuslabv1alpha1.PodTemplate{
+					{
+						Name:       "maven",
+						Containers: []virtuslabv1alpha1.ContainerTemplate{{Name: "maven", Image: "maven"}},
+						Volumes:    []virtuslabv1alpha1.PodTemplateVolume{{MountPath: "/etc/config", ConfigMapName: "config", SecretName: "secret"}},
+					},
+				},
+			},
+			expectedResult: false,
+		},
+	}
+
+	for _, testingData := range data {
+		t.Run(testingData.name, func(t *testing.T) {
+			errs := validateAgents(testingData.agents, field.NewPath("spec", "agents"))
+			assert.Equal(t, testingData.expectedResult, len(errs) == 0, errs)
+		})
+	}
+}
+
+func TestValidateViews(t *testing.T) {
+	nestedViewPlugins := map[string][]string{"nested-view:1.17": {}}
+	data := []struct {
+		name           string
+		views          []virtuslabv1alpha1.View
+		plugins        map[string][]string
+		expectedResult bool
+	}{
+		{
+			name:           "no views",
+			expectedResult: true,
+		},
+		{
+			name: "valid list and nested views",
+			views: []virtuslabv1alpha1.View{
+				{Name: "build", IncludeRegex: "build-.*", Recurse: true},
+				{
+					Name:  "teams",
+					Type:  virtuslabv1alpha1.NestedViewType,
+					Views: []virtuslabv1alpha1.View{{Name: "backend", Jobs: []string{"api", "worker"}}},
+				},
+			},
+			plugins:        nestedViewPlugins,
+			expectedResult: true,
+		},
+		{
+			name:           "reserved view name",
+			views:          []virtuslabv1alpha1.View{{Name: "seed-jobs"}},
+			expectedResult: false,
+		},
+		{
+			name:           "duplicated view name",
+			views:          []virtuslabv1alpha1.View{{Name: "build"}, {Name: "build"}},
+			expectedResult: false,
+		},
+		{
+			name:           "invalid view type",
+			views:          []virtuslabv1alpha1.View{{Name: "build", Type: "dashboard"}},
+			expectedResult: false,
+		},
+		{
+			name:           "invalid include regex",
+			views:          []virtuslabv1alpha1.View{{Name: "build", IncludeRegex: "build-("}},
+			expectedResult: false,
+		},
+		{
+			name:           "nested view without nested-view plugin",
+			views:          []virtuslabv1alpha1.View{{Name: "teams", Type: virtuslabv1alpha1.NestedViewType}},
+			expectedResult: false,
+		},
+		{
+			name: "invalid view in nested view",
+			views: []virtuslabv1alpha1.View{
+				{Name: "teams", Type: virtuslabv1alpha1.NestedViewType, Views: []virtuslabv1alpha1.View{{}}},
+			},
+			plugins:        nestedViewPlugins,
+			expectedResult: false,
+		},
+	}
+
+	for _, testingData := range data {
+		t.Run(testingData.name, func(t *testing.T) {
+			errs := validateViews(testingData.views, testingData.plugins, field.NewPath("spec", "views"))
+			assert.Equal(t, testingData.expectedResult, len(errs) == 0, errs)
 		})
 	}
 }
@@ -136,13 +380,13 @@ func TestReconcileJenkinsBaseConfiguration_verifyBackup(t *testing.T) {
 				e := r.k8sClient.Create(context.TODO(), tt.secret)
 				assert.NoError(t, e)
 			}
-			got, err := r.verifyBackup()
+			errs, err := r.verifyBackup()
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
 			}
-			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want, len(errs) == 0, errs)
 		})
 	}
 }
@@ -0,0 +1,263 @@
+package casc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	jenkinsclient "github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/client"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/base/resources"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/jobs"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/status"
+	"github.com/VirtusLab/jenkins-operator/pkg/log"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	jobHashParameterName = "hash"
+)
+
+// watchedObject is the Kubernetes resource provided by user and watched by the operator
+type watchedObject interface {
+	metav1.Object
+	runtime.Object
+}
+
+// ConfigurationAsCode defines API for applying Configuration as Code YAML files via Jenkins job
+type ConfigurationAsCode struct {
+	jenkinsClient jenkinsclient.Jenkins
+	k8sClient     k8s.Client
+	logger        logr.Logger
+}
+
+// New creates ConfigurationAsCode object
+func New(jenkinsClient jenkinsclient.Jenkins, k8sClient k8s.Client, logger logr.Logger) *ConfigurationAsCode {
+	return &ConfigurationAsCode{
+		jenkinsClient: jenkinsClient,
+		k8sClient:     k8sClient,
+		logger:        logger,
+	}
+}
+
+// Ensure configures Jenkins job which applies Configuration as Code and runs it when configuration has changed
+func (c *ConfigurationAsCode) Ensure(jenkins *virtuslabv1alpha1.Jenkins) (done bool, err error) {
+	if len(jenkins.Spec.ConfigurationAsCode.Configurations) == 0 {
+		return true, nil
+	}
+
+	_, created, err := c.jenkinsClient.CreateOrUpdateJob(configurationAsCodeJobXML, constants.ConfigurationAsCodeJobName)
+	if err != nil {
+		return false, err
+	}
+	if created {
+		c.logger.Info(fmt.Sprintf("'%s' job has been created", constants.ConfigurationAsCodeJobName))
+	}
+
+	configurations, err := c.getConfigurations(jenkins)
+	if err != nil {
+		return false, err
+	}
+	secrets, err := c.getSecrets(jenkins)
+	if err != nil {
+		return false, err
+	}
+
+	hash := calculateHash(configurations, secrets)
+	jobsClient := jobs.New(c.jenkinsClient, c.k8sClient, c.logger)
+	done, err = jobsClient.EnsureBuildJob(constants.ConfigurationAsCodeJobName, hash, map[string]string{jobHashParameterName: hash}, jenkins, true)
+	if err == jobs.ErrorBuildFailed || err == jobs.ErrorUnrecoverableBuildFailed {
+		if updateErr := c.updateErrorInStatus(jenkins, hash); updateErr != nil {
+			return false, updateErr
+		}
+		return false, err
+	} else if err != nil {
+		return false, err
+	}
+
+	if done && len(jenkins.Status.ConfigurationAsCodeError) > 0 {
+		jenkins.Status.ConfigurationAsCodeError = ""
+		if err = status.Update(c.k8sClient, jenkins); err != nil {
+			return false, err
+		}
+	}
+
+	return done, nil
+}
+
+// getConfigurations returns merged data of all config maps with Configuration as Code YAML files,
+// the config maps are labeled to trigger reconciliation loop when they change
+func (c *ConfigurationAsCode) getConfigurations(jenkins *virtuslabv1alpha1.Jenkins) (map[string]string, error) {
+	data := map[string]string{}
+	for _, configMapRef := range jenkins.Spec.ConfigurationAsCode.Configurations {
+		configMap := &corev1.ConfigMap{}
+		err := c.k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: configMapRef.Name}, configMap)
+		if err != nil {
+			return nil, err
+		}
+		if err = c.ensureLabelsForWatchedResource(configMap, jenkins); err != nil {
+			return nil, err
+		}
+		for key, value := range configMap.Data {
+			data[key] = value
+		}
+	}
+
+	return data, nil
+}
+
+// getSecrets returns data of the secret used to resolve variables in Configuration as Code YAML files,
+// the secret is labeled to trigger reconciliation loop when it changes
+func (c *ConfigurationAsCode) getSecrets(jenkins *virtuslabv1alpha1.Jenkins) (map[string]string, error) {
+	data := map[string]string{}
+	if len(jenkins.Spec.ConfigurationAsCode.Secret.Name) == 0 {
+		return data, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := c.k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: jenkins.Spec.ConfigurationAsCode.Secret.Name}, secret)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.ensureLabelsForWatchedResource(secret, jenkins); err != nil {
+		return nil, err
+	}
+	for key, value := range secret.Data {
+		data[key] = string(value)
+	}
+
+	return data, nil
+}
+
+// ensureLabelsForWatchedResource adds labels required by the operator to watch user resource
+func (c *ConfigurationAsCode) ensureLabelsForWatchedResource(object watchedObject, jenkins *virtuslabv1alpha1.Jenkins) error {
+	if !resources.AddLabelsForWatchedResource(object, jenkins) {
+		return nil
+	}
+
+	return c.k8sClient.Update(context.TODO(), object)
+}
+
+func (c *ConfigurationAsCode) updateErrorInStatus(jenkins *virtuslabv1alpha1.Jenkins, hash string) error {
+	var number int64
+	for _, build := range jenkins.Status.Builds {
+		if build.JobName == constants.ConfigurationAsCodeJobName && build.Hash == hash {
+			number = build.Number
+		}
+	}
+	if number == 0 {
+		return nil
+	}
+
+	jenkinsBuild, err := c.jenkinsClient.GetBuild(constants.ConfigurationAsCodeJobName, number)
+	if err != nil {
+		return err
+	}
+
+	message := jobs.GetErrorMessage(jenkinsBuild.GetConsoleOutput())
+	c.logger.V(log.VWarn).Info(fmt.Sprintf("Configuration as Code couldn't be applied: %s", message))
+	if jenkins.Status.ConfigurationAsCodeError == message {
+		return nil
+	}
+	jenkins.Status.ConfigurationAsCodeError = message
+
+	return status.Update(c.k8sClient, jenkins)
+}
+
+func calculateHash(configurations, secrets map[string]string) string {
+	hash := sha256.New()
+	for _, data := range []map[string]string{configurations, secrets} {
+		var keys []string
+		for key := range data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			hash.Write([]byte(key))
+			hash.Write([]byte(data[key]))
+		}
+	}
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil))
+}
+
+var configurationAsCodeJobXML = `<?xml version='1.1' encoding='UTF-8'?>
+<flow-definition plugin="workflow-job@2.31">
+  <actions/>
+  <description>Apply Configuration as Code</description>
+  <keepDependencies>false</keepDependencies>
+  <properties>
+    <org.jenkinsci.plugins.workflow.job.properties.DisableConcurrentBuildsJobProperty/>
+    <hudson.model.ParametersDefinitionProperty>
+      <parameterDefinitions>
+        <hudson.model.StringParameterDefinition>
+          <name>` + jobHashParameterName + `</name>
+          <description></description>
+          <defaultValue></defaultValue>
+          <trim>false</trim>
+        </hudson.model.StringParameterDefinition>
+      </parameterDefinitions>
+    </hudson.model.ParametersDefinitionProperty>
+  </properties>
+  <definition class="org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition" plugin="workflow-cps@2.61">
+    <script>def configurationsPath = &apos;` + resources.JenkinsConfigurationAsCodeVolumePath + `&apos;
+def secretsPath = &apos;` + resources.JenkinsConfigurationAsCodeSecretsVolumePath + `&apos;
+def expectedHash = params.hash
+
+node(&apos;master&apos;) {
+    stage(&apos;Synchronizing files&apos;) {
+        def complete = false
+        for(int i = 1; i &lt;= 10; i++) {
+            def actualHash = calculateHash(configurationsPath, secretsPath)
+            println &quot;Expected hash &apos;${expectedHash}&apos;, actual hash &apos;${actualHash}&apos;&quot;
+            if(expectedHash == actualHash) {
+                complete = true
+                break
+            }
+            sleep 2
+        }
+        if(!complete) {
+            error(&quot;Timeout while synchronizing files&quot;)
+        }
+    }
+
+    stage(&apos;Apply configuration&apos;) {
+        applyConfiguration(configurationsPath)
+    }
+}
+
+@NonCPS
+def calculateHash(String configurationsPath, String secretsPath) {
+    def hash = java.security.MessageDigest.getInstance(&quot;SHA-256&quot;)
+    for(path in [configurationsPath, secretsPath]) {
+        def directory = new File(path)
+        if(!directory.exists()) {
+            continue
+        }
+        def files = directory.list().findAll { !it.startsWith(&apos;.&apos;) }.sort()
+        for(file in files) {
+            hash.update(file.getBytes())
+            hash.update(java.nio.file.Files.readAllBytes(java.nio.file.Paths.get(path, file)))
+        }
+    }
+    return Base64.getEncoder().encodeToString(hash.digest())
+}
+
+@NonCPS
+def applyConfiguration(String configurationsPath) {
+    io.jenkins.plugins.casc.ConfigurationAsCode.get().configure(configurationsPath)
+}</script>
+    <sandbox>false</sandbox>
+  </definition>
+  <triggers/>
+  <disabled>false</disabled>
+</flow-definition>
+`
@@ -0,0 +1,18 @@
+package casc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateHash(t *testing.T) {
+	configurations := map[string]string{"1-jenkins.yaml": "jenkins:", "2-tools.yaml": "tool:"}
+	secrets := map[string]string{"PASSWORD": "secret"}
+
+	hash := calculateHash(configurations, secrets)
+
+	assert.Equal(t, hash, calculateHash(configurations, secrets))
+	assert.NotEqual(t, hash, calculateHash(configurations, map[string]string{"PASSWORD": "changed"}))
+	assert.NotEqual(t, hash, calculateHash(map[string]string{"1-jenkins.yaml": "jenkins:"}, secrets))
+}
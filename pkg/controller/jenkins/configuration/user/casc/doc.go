@@ -0,0 +1,2 @@
+// Package casc implements Jenkins configuration via Configuration as Code Jenkins plugin
+package casc
@@ -0,0 +1,347 @@
+package credentials
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	jenkinsclient "github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/client"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/base/resources"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/jobs"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// UsernameSecretKey defines key of username in credential secret
+	UsernameSecretKey = "username"
+	// PasswordSecretKey defines key of password in credential secret
+	PasswordSecretKey = "password"
+	// SecretSecretKey defines key of secret text in credential secret
+	SecretSecretKey = "secret"
+	// PrivateKeySecretKey defines key of SSH private key in credential secret
+	PrivateKeySecretKey = "privateKey"
+	// PassphraseSecretKey defines key of SSH private key passphrase in credential secret
+	PassphraseSecretKey = "passphrase"
+	// FileNameSecretKey defines key of file name in credential secret
+	FileNameSecretKey = "fileName"
+	// DataSecretKey defines key of file content in credential secret
+	DataSecretKey = "data"
+	// CertificateSecretKey defines key of PKCS#12 certificate in credential secret
+	CertificateSecretKey = "certificate"
+
+	jobHashParameterName = "hash"
+
+	// managedCredentialsFileName is the file in Jenkins home with IDs of credentials managed by operator
+	managedCredentialsFileName = "jenkins-operator-managed-credentials.txt"
+)
+
+// secretKeys defines required and optional keys of credential secret
+type secretKeys struct {
+	required []string
+	optional []string
+}
+
+var credentialSecretKeys = map[virtuslabv1alpha1.CredentialType]secretKeys{
+	virtuslabv1alpha1.UsernamePasswordCredentialType: {required: []string{UsernameSecretKey, PasswordSecretKey}},
+	virtuslabv1alpha1.SecretTextCredentialType:       {required: []string{SecretSecretKey}},
+	virtuslabv1alpha1.SSHKeyCredentialType:           {required: []string{UsernameSecretKey, PrivateKeySecretKey}, optional: []string{PassphraseSecretKey}},
+	virtuslabv1alpha1.SecretFileCredentialType:       {required: []string{FileNameSecretKey, DataSecretKey}},
+	virtuslabv1alpha1.CertificateCredentialType:      {required: []string{CertificateSecretKey}, optional: []string{PasswordSecretKey}},
+}
+
+// GetRequiredSecretKeys returns keys which must be present in the secret of given credential type
+func GetRequiredSecretKeys(credentialType virtuslabv1alpha1.CredentialType) []string {
+	return credentialSecretKeys[credentialType].required
+}
+
+// credentialIndexEntry describes single credential in the managed credentials secret, it never contains secret data
+type credentialIndexEntry struct {
+	ID          string                           `json:"id"`
+	Description string                           `json:"description"`
+	Type        virtuslabv1alpha1.CredentialType `json:"type"`
+}
+
+// Credentials defines API for synchronizing Jenkins credentials from Kubernetes secrets
+type Credentials struct {
+	jenkinsClient jenkinsclient.Jenkins
+	k8sClient     k8s.Client
+	logger        logr.Logger
+}
+
+// New creates Credentials object
+func New(jenkinsClient jenkinsclient.Jenkins, k8sClient k8s.Client, logger logr.Logger) *Credentials {
+	return &Credentials{
+		jenkinsClient: jenkinsClient,
+		k8sClient:     k8sClient,
+		logger:        logger,
+	}
+}
+
+// Ensure copies credentials from referenced secrets to the managed credentials secret and runs Jenkins job
+// which synchronizes them with Jenkins credentials store when they have changed
+func (c *Credentials) Ensure(jenkins *virtuslabv1alpha1.Jenkins) (done bool, err error) {
+	if len(jenkins.Spec.Credentials) == 0 && !c.hasBuild(jenkins) {
+		// nothing to synchronize or remove
+		return true, nil
+	}
+
+	_, created, err := c.jenkinsClient.CreateOrUpdateJob(credentialsJobXML, constants.CredentialsJobName)
+	if err != nil {
+		return false, err
+	}
+	if created {
+		c.logger.Info(fmt.Sprintf("'%s' job has been created", constants.CredentialsJobName))
+	}
+
+	data, err := c.buildManagedCredentialsSecretData(jenkins)
+	if err != nil {
+		return false, err
+	}
+
+	managedSecret := &corev1.Secret{}
+	err = c.k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: resources.GetManagedCredentialsSecretName(jenkins)}, managedSecret)
+	if err != nil {
+		return false, err
+	}
+	if !reflect.DeepEqual(managedSecret.Data, data) {
+		managedSecret.Data = data
+		if err = c.k8sClient.Update(context.TODO(), managedSecret); err != nil {
+			return false, err
+		}
+	}
+
+	hash := calculateHash(data)
+	jobsClient := jobs.New(c.jenkinsClient, c.k8sClient, c.logger)
+	return jobsClient.EnsureBuildJob(constants.CredentialsJobName, hash, map[string]string{jobHashParameterName: hash}, jenkins, true)
+}
+
+func (c *Credentials) hasBuild(jenkins *virtuslabv1alpha1.Jenkins) bool {
+	for _, build := range jenkins.Status.Builds {
+		if build.JobName == constants.CredentialsJobName {
+			return true
+		}
+	}
+	return false
+}
+
+// buildManagedCredentialsSecretData collects data of all credentials from referenced secrets,
+// the referenced secrets are labeled to trigger reconciliation loop when they change
+func (c *Credentials) buildManagedCredentialsSecretData(jenkins *virtuslabv1alpha1.Jenkins) (map[string][]byte, error) {
+	data := map[string][]byte{}
+	var index []credentialIndexEntry
+	for _, credential := range jenkins.Spec.Credentials {
+		secret := &corev1.Secret{}
+		err := c.k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: credential.SecretRef.Name}, secret)
+		if err != nil {
+			return nil, err
+		}
+		if resources.AddLabelsForWatchedResource(secret, jenkins) {
+			if err = c.k8sClient.Update(context.TODO(), secret); err != nil {
+				return nil, err
+			}
+		}
+
+		keys := credentialSecretKeys[credential.Type]
+		for _, key := range append(keys.required, keys.optional...) {
+			if value, found := secret.Data[key]; found {
+				data[resources.GetManagedCredentialsSecretKey(credential.ID, key)] = value
+			}
+		}
+		index = append(index, credentialIndexEntry{
+			ID:          credential.ID,
+			Description: credential.Description,
+			Type:        credential.Type,
+		})
+	}
+
+	if index == nil {
+		index = []credentialIndexEntry{}
+	}
+	indexData, err := json.Marshal(index)
+	if err != nil {
+		return nil, err
+	}
+	data[resources.ManagedCredentialsSecretIndexKey] = indexData
+
+	return data, nil
+}
+
+func calculateHash(data map[string][]byte) string {
+	hash := sha256.New()
+
+	var keys []string
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		hash.Write([]byte(key))
+		hash.Write(data[key])
+	}
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil))
+}
+
+var credentialsJobXML = `<?xml version='1.1' encoding='UTF-8'?>
+<flow-definition plugin="workflow-job@2.31">
+  <actions/>
+  <description>Synchronize credentials</description>
+  <keepDependencies>false</keepDependencies>
+  <properties>
+    <org.jenkinsci.plugins.workflow.job.properties.DisableConcurrentBuildsJobProperty/>
+    <hudson.model.ParametersDefinitionProperty>
+      <parameterDefinitions>
+        <hudson.model.StringParameterDefinition>
+          <name>` + jobHashParameterName + `</name>
+          <description></description>
+          <defaultValue></defaultValue>
+          <trim>false</trim>
+        </hudson.model.StringParameterDefinition>
+      </parameterDefinitions>
+    </hudson.model.ParametersDefinitionProperty>
+  </properties>
+  <definition class="org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition" plugin="workflow-cps@2.61">
+    <script>import com.cloudbees.jenkins.plugins.sshcredentials.impl.BasicSSHUserPrivateKey
+import com.cloudbees.plugins.credentials.CredentialsScope
+import com.cloudbees.plugins.credentials.SecretBytes
+import com.cloudbees.plugins.credentials.SystemCredentialsProvider
+import com.cloudbees.plugins.credentials.common.IdCredentials
+import com.cloudbees.plugins.credentials.domains.Domain
+import com.cloudbees.plugins.credentials.impl.CertificateCredentialsImpl
+import com.cloudbees.plugins.credentials.impl.UsernamePasswordCredentialsImpl
+import groovy.json.JsonSlurperClassic
+import hudson.util.Secret
+import org.jenkinsci.plugins.plaincredentials.impl.FileCredentialsImpl
+import org.jenkinsci.plugins.plaincredentials.impl.StringCredentialsImpl
+
+def credentialsPath = &apos;` + resources.JenkinsManagedCredentialsVolumePath + `&apos;
+def expectedHash = params.hash
+
+node(&apos;master&apos;) {
+    stage(&apos;Synchronizing files&apos;) {
+        def complete = false
+        for(int i = 1; i &lt;= 10; i++) {
+            def actualHash = calculateHash(credentialsPath)
+            println &quot;Expected hash &apos;${expectedHash}&apos;, actual hash &apos;${actualHash}&apos;&quot;
+            if(expectedHash == actualHash) {
+                complete = true
+                break
+            }
+            sleep 2
+        }
+        if(!complete) {
+            error(&quot;Timeout while synchronizing files&quot;)
+        }
+    }
+
+    stage(&apos;Synchronize credentials&apos;) {
+        synchronizeCredentials(credentialsPath, &quot;${env.JENKINS_HOME}/` + managedCredentialsFileName + `&quot;)
+    }
+}
+
+@NonCPS
+def calculateHash(String credentialsPath) {
+    def hash = java.security.MessageDigest.getInstance(&quot;SHA-256&quot;)
+    def files = new File(credentialsPath).list().findAll { !it.startsWith(&apos;.&apos;) }.sort()
+    for(file in files) {
+        hash.update(file.getBytes())
+        hash.update(java.nio.file.Files.readAllBytes(java.nio.file.Paths.get(credentialsPath, file)))
+    }
+    return Base64.getEncoder().encodeToString(hash.digest())
+}
+
+@NonCPS
+def readBytes(String credentialsPath, String id, String key) {
+    def file = new File(credentialsPath, &quot;${id}.${key}&quot;)
+    if(!file.exists()) {
+        return null
+    }
+    return file.getBytes()
+}
+
+@NonCPS
+def readText(String credentialsPath, String id, String key) {
+    def bytes = readBytes(credentialsPath, id, key)
+    if(bytes == null) {
+        return null
+    }
+    return new String(bytes, &apos;UTF-8&apos;)
+}
+
+@NonCPS
+def buildCredential(String credentialsPath, Map credential) {
+    def id = credential.id
+    def description = credential.description ?: &apos;&apos;
+    switch(credential.type) {
+        case &apos;` + string(virtuslabv1alpha1.UsernamePasswordCredentialType) + `&apos;:
+            return new UsernamePasswordCredentialsImpl(CredentialsScope.GLOBAL, id, description,
+                    readText(credentialsPath, id, &apos;` + UsernameSecretKey + `&apos;), readText(credentialsPath, id, &apos;` + PasswordSecretKey + `&apos;))
+        case &apos;` + string(virtuslabv1alpha1.SecretTextCredentialType) + `&apos;:
+            return new StringCredentialsImpl(CredentialsScope.GLOBAL, id, description,
+                    Secret.fromString(readText(credentialsPath, id, &apos;` + SecretSecretKey + `&apos;)))
+        case &apos;` + string(virtuslabv1alpha1.SSHKeyCredentialType) + `&apos;:
+            return new BasicSSHUserPrivateKey(CredentialsScope.GLOBAL, id, readText(credentialsPath, id, &apos;` + UsernameSecretKey + `&apos;),
+                    new BasicSSHUserPrivateKey.DirectEntryPrivateKeySource(readText(credentialsPath, id, &apos;` + PrivateKeySecretKey + `&apos;)),
+                    readText(credentialsPath, id, &apos;` + PassphraseSecretKey + `&apos;), description)
+        case &apos;` + string(virtuslabv1alpha1.SecretFileCredentialType) + `&apos;:
+            return new FileCredentialsImpl(CredentialsScope.GLOBAL, id, description, readText(credentialsPath, id, &apos;` + FileNameSecretKey + `&apos;),
+                    SecretBytes.fromBytes(readBytes(credentialsPath, id, &apos;` + DataSecretKey + `&apos;)))
+        case &apos;` + string(virtuslabv1alpha1.CertificateCredentialType) + `&apos;:
+            return new CertificateCredentialsImpl(CredentialsScope.GLOBAL, id, description, readText(credentialsPath, id, &apos;` + PasswordSecretKey + `&apos;),
+                    new CertificateCredentialsImpl.UploadedKeyStoreSource(SecretBytes.fromBytes(readBytes(credentialsPath, id, &apos;` + CertificateSecretKey + `&apos;))))
+        default:
+            throw new IllegalArgumentException(&quot;Unsupported credential type &apos;${credential.type}&apos;&quot;)
+    }
+}
+
+@NonCPS
+def synchronizeCredentials(String credentialsPath, String managedCredentialsFile) {
+    def credentials = new JsonSlurperClassic().parse(new File(credentialsPath, &apos;` + resources.ManagedCredentialsSecretIndexKey + `&apos;))
+    def store = SystemCredentialsProvider.getInstance().getStore()
+    def domain = Domain.global()
+    def existingCredentials = [:]
+    for(existingCredential in store.getCredentials(domain)) {
+        if(existingCredential instanceof IdCredentials) {
+            existingCredentials[existingCredential.getId()] = existingCredential
+        }
+    }
+
+    def managedCredentials = []
+    for(credential in credentials) {
+        def newCredential = buildCredential(credentialsPath, credential)
+        if(existingCredentials.containsKey(credential.id)) {
+            store.updateCredentials(domain, existingCredentials[credential.id], newCredential)
+        } else {
+            store.addCredentials(domain, newCredential)
+        }
+        managedCredentials.add(credential.id)
+        println &quot;Credential &apos;${credential.id}&apos; has been synchronized&quot;
+    }
+
+    // remove credentials which were managed by operator and have been removed from Jenkins CR
+    def file = new File(managedCredentialsFile)
+    if(file.exists()) {
+        for(id in file.readLines()) {
+            if(!managedCredentials.contains(id) &amp;&amp; existingCredentials.containsKey(id)) {
+                store.removeCredentials(domain, existingCredentials[id])
+                println &quot;Credential &apos;${id}&apos; has been removed&quot;
+            }
+        }
+    }
+    file.text = managedCredentials.join(&apos;\n&apos;)
+}</script>
+    <sandbox>false</sandbox>
+  </definition>
+  <triggers/>
+  <disabled>false</disabled>
+</flow-definition>
+`
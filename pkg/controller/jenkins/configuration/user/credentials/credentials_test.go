@@ -0,0 +1,75 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/base/resources"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func TestCredentials_buildManagedCredentialsSecretData(t *testing.T) {
+	jenkins := &virtuslabv1alpha1.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jenkins",
+			Namespace: "default",
+		},
+		Spec: virtuslabv1alpha1.JenkinsSpec{
+			Credentials: []virtuslabv1alpha1.Credential{
+				{
+					ID:          "github",
+					Description: "GitHub user",
+					Type:        virtuslabv1alpha1.UsernamePasswordCredentialType,
+					SecretRef:   virtuslabv1alpha1.SecretRef{Name: "github"},
+				},
+				{
+					ID:        "deploy-key",
+					Type:      virtuslabv1alpha1.SSHKeyCredentialType,
+					SecretRef: virtuslabv1alpha1.SecretRef{Name: "deploy-key"},
+				},
+			},
+		},
+	}
+	fakeClient := fake.NewFakeClient()
+	err := fakeClient.Create(context.TODO(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "default"},
+		Data: map[string][]byte{
+			UsernameSecretKey: []byte("user"),
+			PasswordSecretKey: []byte("password"),
+			"unused":          []byte("unused"),
+		},
+	})
+	assert.NoError(t, err)
+	err = fakeClient.Create(context.TODO(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "deploy-key", Namespace: "default"},
+		Data: map[string][]byte{
+			UsernameSecretKey:   []byte("git"),
+			PrivateKeySecretKey: []byte("private-key"),
+		},
+	})
+	assert.NoError(t, err)
+	credentialsClient := New(nil, fakeClient, logf.ZapLogger(false))
+
+	data, err := credentialsClient.buildManagedCredentialsSecretData(jenkins)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		resources.ManagedCredentialsSecretIndexKey: []byte(`[{"id":"github","description":"GitHub user","type":"usernamePassword"},{"id":"deploy-key","description":"","type":"sshKey"}]`),
+		"github.username":                          []byte("user"),
+		"github.password":                          []byte("password"),
+		"deploy-key.username":                      []byte("git"),
+		"deploy-key.privateKey":                    []byte("private-key"),
+	}, data)
+
+	secret := &corev1.Secret{}
+	err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: "github", Namespace: "default"}, secret)
+	assert.NoError(t, err)
+	assert.True(t, resources.VerifyLabelsForWatchedResource(secret, jenkins))
+}
@@ -0,0 +1,2 @@
+// Package credentials implements synchronization of Jenkins credentials from Kubernetes secrets
+package credentials
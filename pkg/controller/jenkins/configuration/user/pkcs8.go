@@ -0,0 +1,130 @@
+package user
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// encryptedPKCS8PrivateKeyType is the PEM block type of PKCS#8 private key protected by passphrase
+const encryptedPKCS8PrivateKeyType = "ENCRYPTED PRIVATE KEY"
+
+var (
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidAES128CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+// encryptedPrivateKeyInfo is defined in RFC 5208
+type encryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+// pbes2Params is defined in RFC 8018
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params is defined in RFC 8018, HMAC-SHA1 is used when PRF is not set
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// decryptPKCS8PrivateKey decrypts PKCS#8 private key encrypted with PBES2 scheme using PBKDF2 and AES-CBC,
+// which is the default of 'openssl pkcs8 -topk8' and 'openssl genpkey' since OpenSSL 1.1
+func decryptPKCS8PrivateKey(der []byte, passphrase string) (interface{}, error) {
+	var keyInfo encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &keyInfo); err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted PKCS#8 private key: %s", err)
+	}
+	if !keyInfo.EncryptionAlgorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("unsupported PKCS#8 encryption algorithm '%s', only PBES2 is supported", keyInfo.EncryptionAlgorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(keyInfo.EncryptionAlgorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("failed to parse PBES2 parameters: %s", err)
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("unsupported key derivation function '%s', only PBKDF2 is supported", params.KeyDerivationFunc.Algorithm)
+	}
+	var kdfParams pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdfParams); err != nil {
+		return nil, fmt.Errorf("failed to parse PBKDF2 parameters: %s", err)
+	}
+
+	var prf func() hash.Hash
+	switch {
+	case len(kdfParams.PRF.Algorithm) == 0 || kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA1):
+		prf = sha1.New
+	case kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA256):
+		prf = sha256.New
+	default:
+		return nil, fmt.Errorf("unsupported PBKDF2 pseudorandom function '%s'", kdfParams.PRF.Algorithm)
+	}
+
+	var keyLength int
+	switch {
+	case params.EncryptionScheme.Algorithm.Equal(oidAES128CBC):
+		keyLength = 16
+	case params.EncryptionScheme.Algorithm.Equal(oidAES192CBC):
+		keyLength = 24
+	case params.EncryptionScheme.Algorithm.Equal(oidAES256CBC):
+		keyLength = 32
+	default:
+		return nil, fmt.Errorf("unsupported encryption scheme '%s', only AES-CBC is supported", params.EncryptionScheme.Algorithm)
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("failed to parse AES-CBC parameters: %s", err)
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, errors.New("invalid AES-CBC initialization vector")
+	}
+	if len(keyInfo.EncryptedData) == 0 || len(keyInfo.EncryptedData)%aes.BlockSize != 0 {
+		return nil, errors.New("invalid length of encrypted PKCS#8 private key")
+	}
+
+	key := pbkdf2.Key([]byte(passphrase), kdfParams.Salt, kdfParams.IterationCount, keyLength, prf)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, len(keyInfo.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(data, keyInfo.EncryptedData)
+
+	// wrong passphrase results in invalid PKCS#7 padding or invalid private key
+	errDecryption := errors.New("failed to decrypt PKCS#8 private key, passphrase is invalid")
+	padding := int(data[len(data)-1])
+	if padding == 0 || padding > aes.BlockSize {
+		return nil, errDecryption
+	}
+	for _, b := range data[len(data)-padding:] {
+		if int(b) != padding {
+			return nil, errDecryption
+		}
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(data[:len(data)-padding])
+	if err != nil {
+		return nil, errDecryption
+	}
+	return privateKey, nil
+}
@@ -2,16 +2,21 @@ package user
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/backup"
 	jenkinsclient "github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/client"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/base/resources"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/user/casc"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/user/credentials"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/user/seedjobs"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/groovy"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/jobs"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/status"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -54,6 +59,15 @@ func (r *ReconcileUserConfiguration) Reconcile() (reconcile.Result, error) {
 		return result, nil
 	}
 
+	// credentials can be used by seed jobs so they are synchronized first
+	result, err = r.ensureCredentials()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if result.Requeue {
+		return result, nil
+	}
+
 	// reconcile seed jobs
 	result, err = r.ensureSeedJobs()
 	if err != nil {
@@ -63,6 +77,14 @@ func (r *ReconcileUserConfiguration) Reconcile() (reconcile.Result, error) {
 		return result, nil
 	}
 
+	result, err = r.ensureConfigurationAsCode()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if result.Requeue {
+		return result, nil
+	}
+
 	result, err = r.ensureUserConfiguration(r.jenkinsClient)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -101,14 +123,64 @@ func (r *ReconcileUserConfiguration) ensureSeedJobs() (reconcile.Result, error)
 	return reconcile.Result{}, nil
 }
 
+func (r *ReconcileUserConfiguration) ensureCredentials() (reconcile.Result, error) {
+	credentialsClient := credentials.New(r.jenkinsClient, r.k8sClient, r.logger)
+	done, err := credentialsClient.Ensure(r.jenkins)
+	if err != nil {
+		// build failed and can be recovered - retry build and requeue reconciliation loop with timeout
+		if err == jobs.ErrorBuildFailed {
+			return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 10}, nil
+		}
+		// build failed and cannot be recovered
+		if err == jobs.ErrorUnrecoverableBuildFailed {
+			return reconcile.Result{}, nil
+		}
+		// unexpected error - requeue reconciliation loop
+		return reconcile.Result{}, err
+	}
+	// build not finished yet - requeue reconciliation loop with timeout
+	if !done {
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 10}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcileUserConfiguration) ensureConfigurationAsCode() (reconcile.Result, error) {
+	configurationAsCode := casc.New(r.jenkinsClient, r.k8sClient, r.logger)
+	done, err := configurationAsCode.Ensure(r.jenkins)
+	if err != nil {
+		// build failed and can be recovered - retry build and requeue reconciliation loop with timeout
+		if err == jobs.ErrorBuildFailed {
+			return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 10}, nil
+		}
+		// build failed and cannot be recovered - error is reported in Jenkins CR status
+		if err == jobs.ErrorUnrecoverableBuildFailed {
+			return reconcile.Result{}, nil
+		}
+		// unexpected error - requeue reconciliation loop
+		return reconcile.Result{}, err
+	}
+	// build not finished yet - requeue reconciliation loop with timeout
+	if !done {
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 10}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
 func (r *ReconcileUserConfiguration) ensureUserConfiguration(jenkinsClient jenkinsclient.Jenkins) (reconcile.Result, error) {
-	groovyClient := groovy.New(jenkinsClient, r.k8sClient, r.logger, constants.UserConfigurationJobName, resources.JenkinsUserConfigurationVolumePath)
+	groovyClient := groovy.New(jenkinsClient, r.k8sClient, r.logger, constants.UserConfigurationJobName,
+		resources.JenkinsUserConfigurationVolumePath, resources.JenkinsUserConfigurationSecretsVolumePath)
 
 	err := groovyClient.ConfigureGroovyJob()
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
+	secretsData, err := r.getUserConfigurationSecretsData()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	configuration := &corev1.ConfigMap{}
 	namespaceName := types.NamespacedName{Namespace: r.jenkins.Namespace, Name: resources.GetUserConfigurationConfigMapName(r.jenkins)}
 	err = r.k8sClient.Get(context.TODO(), namespaceName, configuration)
@@ -116,7 +188,7 @@ func (r *ReconcileUserConfiguration) ensureUserConfiguration(jenkinsClient jenki
 		return reconcile.Result{}, err
 	}
 
-	done, err := groovyClient.EnsureGroovyJob(configuration.Data, r.jenkins)
+	done, hash, err := groovyClient.EnsureGroovyJobAfter("", configuration.Data, secretsData, r.jenkins)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -125,5 +197,145 @@ func (r *ReconcileUserConfiguration) ensureUserConfiguration(jenkinsClient jenki
 		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 10}, nil
 	}
 
+	// sources are applied one by one in the given order, every source has its own job which is applied again
+	// when the source or any configuration applied before it changes
+	for _, source := range r.jenkins.Spec.UserConfiguration.Sources {
+		done, hash, err = r.ensureUserConfigurationSource(jenkinsClient, source, secretsData, hash)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
+		if !done {
+			return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 10}, nil
+		}
+	}
+
+	err = r.removeUserConfigurationSourceJobs(jenkinsClient)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	return reconcile.Result{}, nil
 }
+
+func (r *ReconcileUserConfiguration) ensureUserConfigurationSource(jenkinsClient jenkinsclient.Jenkins,
+	source virtuslabv1alpha1.UserConfigurationSource, secretsData map[string]string, previousHash string) (bool, string, error) {
+	groovyClient := groovy.New(jenkinsClient, r.k8sClient, r.logger, getUserConfigurationSourceJobName(source),
+		resources.GetUserConfigurationSourceVolumePath(source), resources.JenkinsUserConfigurationSecretsVolumePath)
+
+	err := groovyClient.ConfigureGroovyJob()
+	if err != nil {
+		return false, "", err
+	}
+
+	data, err := r.getUserConfigurationSourceData(source)
+	if err != nil {
+		return false, "", err
+	}
+
+	return groovyClient.EnsureGroovyJobAfter(previousHash, data, secretsData, r.jenkins)
+}
+
+// removeUserConfigurationSourceJobs deletes jobs of user configuration sources removed from Jenkins CR
+// together with their builds kept in Jenkins CR status
+func (r *ReconcileUserConfiguration) removeUserConfigurationSourceJobs(jenkinsClient jenkinsclient.Jenkins) error {
+	definedJobNames := map[string]bool{}
+	for _, source := range r.jenkins.Spec.UserConfiguration.Sources {
+		definedJobNames[getUserConfigurationSourceJobName(source)] = true
+	}
+
+	jobNames, err := jenkinsClient.GetAllJobNames()
+	if err != nil {
+		return err
+	}
+
+	removedJobNames := map[string]bool{}
+	for _, job := range jobNames {
+		if !strings.HasPrefix(job.Name, constants.UserConfigurationJobName+"-") || definedJobNames[job.Name] {
+			continue
+		}
+		if _, err := jenkinsClient.DeleteJob(job.Name); err != nil && !jenkinsclient.IsNotFoundError(err) {
+			return err
+		}
+		r.logger.Info(fmt.Sprintf("'%s' job of removed user configuration source has been deleted", job.Name))
+		removedJobNames[job.Name] = true
+	}
+
+	var builds []virtuslabv1alpha1.Build
+	for _, build := range r.jenkins.Status.Builds {
+		if removedJobNames[build.JobName] {
+			continue
+		}
+		builds = append(builds, build)
+	}
+	if len(builds) == len(r.jenkins.Status.Builds) {
+		return nil
+	}
+	r.jenkins.Status.Builds = builds
+	return status.Update(r.k8sClient, r.jenkins)
+}
+
+// getUserConfigurationSourceJobName returns name of the job which applies groovy scripts of user configuration source
+func getUserConfigurationSourceJobName(source virtuslabv1alpha1.UserConfigurationSource) string {
+	return fmt.Sprintf("%s-%s", constants.UserConfigurationJobName, resources.GetUserConfigurationSourceName(source))
+}
+
+// getUserConfigurationSecretsData returns content of all secrets exposed to groovy scripts in '<secret name>/<key>' format,
+// the data is only used to calculate configuration hash and must never be logged
+func (r *ReconcileUserConfiguration) getUserConfigurationSecretsData() (map[string]string, error) {
+	data := map[string]string{}
+	for _, secretRef := range r.jenkins.Spec.UserConfiguration.Secrets {
+		secret := &corev1.Secret{}
+		namespaceName := types.NamespacedName{Namespace: r.jenkins.Namespace, Name: secretRef.Name}
+		if err := r.k8sClient.Get(context.TODO(), namespaceName, secret); err != nil {
+			return nil, err
+		}
+		if resources.AddLabelsForWatchedResource(secret, r.jenkins) {
+			if err := r.k8sClient.Update(context.TODO(), secret); err != nil {
+				return nil, err
+			}
+		}
+		for key, value := range secret.Data {
+			data[fmt.Sprintf("%s/%s", secretRef.Name, key)] = string(value)
+		}
+	}
+
+	return data, nil
+}
+
+// getUserConfigurationSourceData returns groovy scripts from config map or secret,
+// the resource is labeled to trigger reconciliation loop when it changes
+func (r *ReconcileUserConfiguration) getUserConfigurationSourceData(source virtuslabv1alpha1.UserConfigurationSource) (map[string]string, error) {
+	data := map[string]string{}
+	if source.SecretRef != nil {
+		secret := &corev1.Secret{}
+		namespaceName := types.NamespacedName{Namespace: r.jenkins.Namespace, Name: source.SecretRef.Name}
+		if err := r.k8sClient.Get(context.TODO(), namespaceName, secret); err != nil {
+			return nil, err
+		}
+		if resources.AddLabelsForWatchedResource(secret, r.jenkins) {
+			if err := r.k8sClient.Update(context.TODO(), secret); err != nil {
+				return nil, err
+			}
+		}
+		for key, value := range secret.Data {
+			data[key] = string(value)
+		}
+		return data, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	namespaceName := types.NamespacedName{Namespace: r.jenkins.Namespace, Name: source.ConfigMapRef.Name}
+	if err := r.k8sClient.Get(context.TODO(), namespaceName, configMap); err != nil {
+		return nil, err
+	}
+	if resources.AddLabelsForWatchedResource(configMap, r.jenkins) {
+		if err := r.k8sClient.Update(context.TODO(), configMap); err != nil {
+			return nil, err
+		}
+	}
+	for key, value := range configMap.Data {
+		data[key] = value
+	}
+	return data, nil
+}
@@ -0,0 +1,70 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/client"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
+
+	"github.com/bndr/gojenkins"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func TestRemoveUserConfigurationSourceJobs(t *testing.T) {
+	// given
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jenkins := &virtuslabv1alpha1.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Spec: virtuslabv1alpha1.JenkinsSpec{
+			UserConfiguration: virtuslabv1alpha1.UserConfiguration{
+				Sources: []virtuslabv1alpha1.UserConfigurationSource{
+					{ConfigMapRef: &virtuslabv1alpha1.ConfigMapRef{Name: "defined"}},
+				},
+			},
+		},
+		Status: virtuslabv1alpha1.JenkinsStatus{
+			Builds: []virtuslabv1alpha1.Build{
+				{JobName: constants.UserConfigurationJobName, Hash: "a"},
+				{JobName: constants.UserConfigurationJobName + "-configmap-defined", Hash: "b"},
+				{JobName: constants.UserConfigurationJobName + "-secret-removed", Hash: "c"},
+			},
+		},
+	}
+	err := virtuslabv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+	k8sClient := fake.NewFakeClient()
+	assert.NoError(t, k8sClient.Create(context.TODO(), jenkins))
+
+	jenkinsClient := client.NewMockJenkins(ctrl)
+	jenkinsClient.EXPECT().GetAllJobNames().Return([]gojenkins.InnerJob{
+		{Name: constants.UserConfigurationJobName},
+		{Name: constants.UserConfigurationJobName + "-configmap-defined"},
+		{Name: constants.UserConfigurationJobName + "-secret-removed"},
+		{Name: constants.ConfigurationAsCodeJobName},
+	}, nil)
+	jenkinsClient.EXPECT().DeleteJob(constants.UserConfigurationJobName+"-secret-removed").Return(true, nil)
+
+	reconciler := New(k8sClient, jenkinsClient, logf.ZapLogger(false), jenkins)
+
+	// when
+	err = reconciler.removeUserConfigurationSourceJobs(jenkinsClient)
+
+	// then
+	assert.NoError(t, err)
+	updated := &virtuslabv1alpha1.Jenkins{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), types.NamespacedName{Name: "jenkins", Namespace: "default"}, updated))
+	assert.Equal(t, []virtuslabv1alpha1.Build{
+		{JobName: constants.UserConfigurationJobName, Hash: "a"},
+		{JobName: constants.UserConfigurationJobName + "-configmap-defined", Hash: "b"},
+	}, updated.Status.Builds)
+}
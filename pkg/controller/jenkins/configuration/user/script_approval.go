@@ -0,0 +1,47 @@
+package user
+
+import (
+	"strings"
+)
+
+const (
+	pendingScriptPrefix    = "script:"
+	pendingSignaturePrefix = "signature:"
+)
+
+// listPendingScriptApprovals prints scripts and signatures waiting for approval in Jenkins script security, one per line
+const listPendingScriptApprovals = `
+import org.jenkinsci.plugins.scriptsecurity.scripts.ScriptApproval
+
+def scriptApproval = ScriptApproval.get()
+scriptApproval.getPendingScripts().each { pendingScript ->
+    println "` + pendingScriptPrefix + `${pendingScript.getHash()}"
+}
+scriptApproval.getPendingSignatures().each { pendingSignature ->
+    println "` + pendingSignaturePrefix + `${pendingSignature.signature}"
+}
+`
+
+// GetPendingScriptApprovals returns hashes of scripts and signatures waiting for approval in Jenkins,
+// they can be approved by adding them to spec.scriptApproval in Jenkins CR
+func (r *ReconcileUserConfiguration) GetPendingScriptApprovals() (scriptHashes []string, signatures []string, err error) {
+	output, err := r.jenkinsClient.ExecuteScript(listPendingScriptApprovals)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scriptHashes, signatures = parsePendingScriptApprovals(output)
+	return scriptHashes, signatures, nil
+}
+
+func parsePendingScriptApprovals(output string) (scriptHashes []string, signatures []string) {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, pendingScriptPrefix) {
+			scriptHashes = append(scriptHashes, strings.TrimPrefix(line, pendingScriptPrefix))
+		} else if strings.HasPrefix(line, pendingSignaturePrefix) {
+			signatures = append(signatures, strings.TrimPrefix(line, pendingSignaturePrefix))
+		}
+	}
+	return scriptHashes, signatures
+}
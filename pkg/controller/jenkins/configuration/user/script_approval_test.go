@@ -0,0 +1,28 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePendingScriptApprovals(t *testing.T) {
+	t.Run("no pending approvals", func(t *testing.T) {
+		scriptHashes, signatures := parsePendingScriptApprovals("")
+
+		assert.Empty(t, scriptHashes)
+		assert.Empty(t, signatures)
+	})
+	t.Run("pending scripts and signatures", func(t *testing.T) {
+		output := "script:3f4e2b1c9a\n" +
+			"signature:method java.lang.String trim\n" +
+			"\n" +
+			"script:a1b2c3d4e5\n" +
+			"Result: [something]\n"
+
+		scriptHashes, signatures := parsePendingScriptApprovals(output)
+
+		assert.Equal(t, []string{"3f4e2b1c9a", "a1b2c3d4e5"}, scriptHashes)
+		assert.Equal(t, []string{"method java.lang.String trim"}, signatures)
+	})
+}
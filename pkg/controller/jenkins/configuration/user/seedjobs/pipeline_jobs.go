@@ -0,0 +1,196 @@
+package seedjobs
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"text/template"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
+
+	"github.com/pkg/errors"
+)
+
+const defaultScriptPath = "Jenkinsfile"
+
+// GetSeedJobType returns type of seed job, Job DSL is used when type is not set
+func GetSeedJobType(seedJob virtuslabv1alpha1.SeedJob) virtuslabv1alpha1.SeedJobType {
+	if len(seedJob.Type) > 0 {
+		return seedJob.Type
+	}
+	return virtuslabv1alpha1.JobDSLSeedJobType
+}
+
+// PipelineJobName returns name of Multibranch Pipeline job or Organization Folder created for seed job,
+// the reserved suffix prevents overwriting jobs created by users or generated by Job DSL
+func PipelineJobName(seedJobID string) string {
+	return fmt.Sprintf("%s-%s", seedJobID, constants.PipelineSeedJobSuffix)
+}
+
+// ensurePipelineJob creates or updates Multibranch Pipeline job or Organization Folder of seed job,
+// newly created job is scheduled to scan its repositories
+func (s *SeedJobs) ensurePipelineJob(seedJob virtuslabv1alpha1.SeedJob) error {
+	config, err := pipelineJobConfigXML(seedJob)
+	if err != nil {
+		return err
+	}
+
+	jobName := PipelineJobName(seedJob.ID)
+	_, created, err := s.jenkinsClient.CreateOrUpdateJob(config, jobName)
+	if err != nil {
+		return err
+	}
+	if created {
+		s.logger.Info(fmt.Sprintf("'%s' %s job has been created", jobName, GetSeedJobType(seedJob)))
+		if _, err = s.jenkinsClient.BuildJob(jobName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pipelineJobConfigXML returns XML representation of Multibranch Pipeline job or Organization Folder
+func pipelineJobConfigXML(seedJob virtuslabv1alpha1.SeedJob) (string, error) {
+	data := struct {
+		SeedJob       virtuslabv1alpha1.SeedJob
+		CredentialsID string
+		ScriptPath    string
+	}{
+		SeedJob: seedJob,
+	}
+	if GetCredentialType(seedJob) != virtuslabv1alpha1.NoSeedJobCredentialType {
+		data.CredentialsID = seedJob.ID
+	}
+
+	var jobTemplate *template.Template
+	switch GetSeedJobType(seedJob) {
+	case virtuslabv1alpha1.MultibranchSeedJobType:
+		jobTemplate = multibranchConfigXMLTemplate
+		data.ScriptPath = seedJob.Multibranch.ScriptPath
+	case virtuslabv1alpha1.OrganizationFolderSeedJobType:
+		jobTemplate = organizationFolderConfigXMLTemplate
+		data.ScriptPath = seedJob.OrganizationFolder.ScriptPath
+	default:
+		return "", errors.Errorf("seed job type '%s' is not pipeline job", GetSeedJobType(seedJob))
+	}
+	if len(data.ScriptPath) == 0 {
+		data.ScriptPath = defaultScriptPath
+	}
+
+	var buffer bytes.Buffer
+	if err := jobTemplate.Execute(&buffer, data); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+func escapeXML(value string) (string, error) {
+	var buffer bytes.Buffer
+	if err := xml.EscapeText(&buffer, []byte(value)); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+var templateFuncs = template.FuncMap{"xml": escapeXML}
+
+var multibranchConfigXMLTemplate = template.Must(template.New("multibranch").Funcs(templateFuncs).Parse(`<?xml version='1.0' encoding='UTF-8'?>
+<org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject plugin="workflow-multibranch">
+  <actions/>
+  <description>{{ xml .SeedJob.Description }}</description>
+  <displayName>{{ xml .SeedJob.ID }}</displayName>
+  <properties/>
+  <folderViews class="jenkins.branch.MultiBranchProjectViewHolder" plugin="branch-api">
+    <owner class="org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject" reference="../.."/>
+  </folderViews>
+  <healthMetrics/>
+  <icon class="jenkins.branch.MetadataActionFolderIcon" plugin="branch-api">
+    <owner class="org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject" reference="../.."/>
+  </icon>
+  <orphanedItemStrategy class="com.cloudbees.hudson.plugins.folder.computed.DefaultOrphanedItemStrategy" plugin="cloudbees-folder">
+    <pruneDeadBranches>true</pruneDeadBranches>
+    <daysToKeep>-1</daysToKeep>
+    <numToKeep>-1</numToKeep>
+  </orphanedItemStrategy>
+  <triggers/>
+  <disabled>false</disabled>
+  <sources class="jenkins.branch.MultiBranchProject$BranchSourceList" plugin="branch-api">
+    <data>
+      <jenkins.branch.BranchSource>
+        <source class="jenkins.plugins.git.GitSCMSource" plugin="git">
+          <id>{{ xml .SeedJob.ID }}</id>
+          <remote>{{ xml .SeedJob.RepositoryURL }}</remote>
+          <credentialsId>{{ xml .CredentialsID }}</credentialsId>
+          <traits>
+            <jenkins.plugins.git.traits.BranchDiscoveryTrait/>
+          </traits>
+        </source>
+        <strategy class="jenkins.branch.DefaultBranchPropertyStrategy">
+          <properties class="empty-list"/>
+        </strategy>
+      </jenkins.branch.BranchSource>
+    </data>
+    <owner class="org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject" reference="../.."/>
+  </sources>
+  <factory class="org.jenkinsci.plugins.workflow.multibranch.WorkflowBranchProjectFactory">
+    <owner class="org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject" reference="../.."/>
+    <scriptPath>{{ xml .ScriptPath }}</scriptPath>
+  </factory>
+</org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject>
+`))
+
+var organizationFolderConfigXMLTemplate = template.Must(template.New("organizationFolder").Funcs(templateFuncs).Parse(`<?xml version='1.0' encoding='UTF-8'?>
+<jenkins.branch.OrganizationFolder plugin="branch-api">
+  <actions/>
+  <description>{{ xml .SeedJob.Description }}</description>
+  <displayName>{{ xml .SeedJob.ID }}</displayName>
+  <properties/>
+  <folderViews class="jenkins.branch.OrganizationFolderViewHolder">
+    <owner reference="../.."/>
+  </folderViews>
+  <healthMetrics/>
+  <icon class="jenkins.branch.MetadataActionFolderIcon">
+    <owner class="jenkins.branch.OrganizationFolder" reference="../.."/>
+  </icon>
+  <orphanedItemStrategy class="com.cloudbees.hudson.plugins.folder.computed.DefaultOrphanedItemStrategy" plugin="cloudbees-folder">
+    <pruneDeadBranches>true</pruneDeadBranches>
+    <daysToKeep>-1</daysToKeep>
+    <numToKeep>-1</numToKeep>
+  </orphanedItemStrategy>
+  <triggers/>
+  <disabled>false</disabled>
+  <navigators>
+{{- if eq .SeedJob.OrganizationFolder.Provider "github" }}
+    <org.jenkinsci.plugins.github__branch__source.GitHubSCMNavigator plugin="github-branch-source">
+      <repoOwner>{{ xml .SeedJob.OrganizationFolder.Owner }}</repoOwner>
+      {{- if .SeedJob.OrganizationFolder.ServerURL }}
+      <apiUri>{{ xml .SeedJob.OrganizationFolder.ServerURL }}</apiUri>
+      {{- end }}
+      <credentialsId>{{ xml .CredentialsID }}</credentialsId>
+      <traits>
+        <org.jenkinsci.plugins.github__branch__source.BranchDiscoveryTrait>
+          <strategyId>1</strategyId>
+        </org.jenkinsci.plugins.github__branch__source.BranchDiscoveryTrait>
+      </traits>
+    </org.jenkinsci.plugins.github__branch__source.GitHubSCMNavigator>
+{{- else if eq .SeedJob.OrganizationFolder.Provider "bitbucket" }}
+    <com.cloudbees.jenkins.plugins.bitbucket.BitbucketSCMNavigator plugin="cloudbees-bitbucket-branch-source">
+      <serverUrl>{{ if .SeedJob.OrganizationFolder.ServerURL }}{{ xml .SeedJob.OrganizationFolder.ServerURL }}{{ else }}https://bitbucket.org{{ end }}</serverUrl>
+      <credentialsId>{{ xml .CredentialsID }}</credentialsId>
+      <repoOwner>{{ xml .SeedJob.OrganizationFolder.Owner }}</repoOwner>
+      <traits>
+        <com.cloudbees.jenkins.plugins.bitbucket.BranchDiscoveryTrait>
+          <strategyId>1</strategyId>
+        </com.cloudbees.jenkins.plugins.bitbucket.BranchDiscoveryTrait>
+      </traits>
+    </com.cloudbees.jenkins.plugins.bitbucket.BitbucketSCMNavigator>
+{{- end }}
+  </navigators>
+  <projectFactories>
+    <org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProjectFactory plugin="workflow-multibranch">
+      <scriptPath>{{ xml .ScriptPath }}</scriptPath>
+    </org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProjectFactory>
+  </projectFactories>
+</jenkins.branch.OrganizationFolder>
+`))
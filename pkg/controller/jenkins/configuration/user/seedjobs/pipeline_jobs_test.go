@@ -0,0 +1,81 @@
+package seedjobs
+
+import (
+	"encoding/xml"
+	"testing"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/core/v1"
+)
+
+func TestPipelineJobName(t *testing.T) {
+	assert.Equal(t, "jenkins-operator-pipeline-seed", PipelineJobName("jenkins-operator"))
+}
+
+func TestPipelineJobConfigXML(t *testing.T) {
+	t.Run("multibranch", func(t *testing.T) {
+		seedJob := virtuslabv1alpha1.SeedJob{
+			ID:            "jenkins-operator",
+			Description:   "Jenkins Operator <multibranch> & more",
+			RepositoryURL: "git@github.com:VirtusLab/jenkins-operator.git",
+			Type:          virtuslabv1alpha1.MultibranchSeedJobType,
+			PrivateKey: virtuslabv1alpha1.PrivateKey{
+				SecretKeyRef: &v1.SecretKeySelector{Key: "privateKey"},
+			},
+		}
+
+		config, err := pipelineJobConfigXML(seedJob)
+
+		assert.NoError(t, err)
+		assert.NoError(t, xml.Unmarshal([]byte(config), new(interface{})))
+		assert.Contains(t, config, "<description>Jenkins Operator &lt;multibranch&gt; &amp; more</description>")
+		assert.Contains(t, config, "<displayName>jenkins-operator</displayName>")
+		assert.Contains(t, config, "<remote>git@github.com:VirtusLab/jenkins-operator.git</remote>")
+		assert.Contains(t, config, "<credentialsId>jenkins-operator</credentialsId>")
+		assert.Contains(t, config, "<scriptPath>Jenkinsfile</scriptPath>")
+	})
+	t.Run("GitHub organization folder", func(t *testing.T) {
+		seedJob := virtuslabv1alpha1.SeedJob{
+			ID:   "virtuslab",
+			Type: virtuslabv1alpha1.OrganizationFolderSeedJobType,
+			OrganizationFolder: virtuslabv1alpha1.OrganizationFolder{
+				Provider:   virtuslabv1alpha1.GitHubOrganizationProvider,
+				Owner:      "VirtusLab",
+				ScriptPath: "cicd/Jenkinsfile",
+			},
+		}
+
+		config, err := pipelineJobConfigXML(seedJob)
+
+		assert.NoError(t, err)
+		assert.NoError(t, xml.Unmarshal([]byte(config), new(interface{})))
+		assert.Contains(t, config, "GitHubSCMNavigator")
+		assert.Contains(t, config, "<repoOwner>VirtusLab</repoOwner>")
+		assert.Contains(t, config, "<credentialsId></credentialsId>")
+		assert.NotContains(t, config, "<apiUri>")
+		assert.Contains(t, config, "<scriptPath>cicd/Jenkinsfile</scriptPath>")
+	})
+	t.Run("Bitbucket organization folder", func(t *testing.T) {
+		seedJob := virtuslabv1alpha1.SeedJob{
+			ID:   "virtuslab",
+			Type: virtuslabv1alpha1.OrganizationFolderSeedJobType,
+			OrganizationFolder: virtuslabv1alpha1.OrganizationFolder{
+				Provider: virtuslabv1alpha1.BitbucketOrganizationProvider,
+				Owner:    "VirtusLab",
+			},
+		}
+
+		config, err := pipelineJobConfigXML(seedJob)
+
+		assert.NoError(t, err)
+		assert.Contains(t, config, "BitbucketSCMNavigator")
+		assert.Contains(t, config, "<serverUrl>https://bitbucket.org</serverUrl>")
+	})
+	t.Run("job DSL", func(t *testing.T) {
+		_, err := pipelineJobConfigXML(virtuslabv1alpha1.SeedJob{ID: "jenkins-operator"})
+
+		assert.Error(t, err)
+	})
+}
@@ -5,6 +5,9 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
 	jenkinsclient "github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/client"
@@ -21,13 +24,32 @@ import (
 const (
 	// ConfigureSeedJobsName this is the fixed seed job name
 	ConfigureSeedJobsName = constants.OperatorName + "-configure-seed-job"
+	// RemoveSeedJobsName is the name of job which removes seed jobs not defined in Jenkins CR
+	RemoveSeedJobsName = constants.OperatorName + "-remove-seed-jobs"
 
 	deployKeyIDParameterName      = "DEPLOY_KEY_ID"
 	privateKeyParameterName       = "PRIVATE_KEY"
+	passphraseParameterName       = "PASSPHRASE"
 	repositoryURLParameterName    = "REPOSITORY_URL"
 	repositoryBranchParameterName = "REPOSITORY_BRANCH"
 	targetsParameterName          = "TARGETS"
 	displayNameParameterName      = "SEED_JOB_DISPLAY_NAME"
+	credentialTypeParameterName   = "CREDENTIAL_TYPE"
+	usernameParameterName         = "USERNAME"
+	passwordParameterName         = "PASSWORD"
+	pollSCMParameterName          = "TRIGGER_POLL_SCM"
+	gitHubPushParameterName       = "TRIGGER_GITHUB_PUSH"
+	gitLabPushParameterName       = "TRIGGER_GITLAB_PUSH"
+	bitbucketPushParameterName    = "TRIGGER_BITBUCKET_PUSH"
+	seedJobTypeParameterName      = "SEED_JOB_TYPE"
+	sandboxParameterName          = "SANDBOX"
+	scriptSecurityParameterName   = "SCRIPT_SECURITY"
+
+	seedJobIDsParameterName          = "SEED_JOB_IDS"
+	removeGeneratedJobsParameterName = "REMOVE_GENERATED_JOBS"
+
+	// defaultTokenUsername is used when username for token is not set, most of Git servers accept any username with token
+	defaultTokenUsername = "token"
 )
 
 // SeedJobs defines API for configuring and ensuring Jenkins Seed Jobs and Deploy Keys
@@ -58,9 +80,96 @@ func (s *SeedJobs) EnsureSeedJobs(jenkins *virtuslabv1alpha1.Jenkins) (done bool
 		s.logger.V(log.VWarn).Info("Couldn't build jenkins seed job")
 		return false, err
 	}
+	if !done {
+		return false, nil
+	}
+	err = s.UpdateStatus(jenkins)
+	if err != nil {
+		s.logger.V(log.VWarn).Info("Couldn't update jenkins seed jobs status")
+		return false, err
+	}
+	done, err = s.removeSeedJobs(jenkins)
+	if err != nil {
+		s.logger.V(log.VWarn).Info("Couldn't remove jenkins seed jobs")
+		return false, err
+	}
 	return done, nil
 }
 
+// removeSeedJobs deletes seed jobs which exist in Jenkins but are not defined in Jenkins CR,
+// their credentials and optionally generated jobs according to Jenkins.Spec.SeedJobsRemovalPolicy
+func (s *SeedJobs) removeSeedJobs(jenkins *virtuslabv1alpha1.Jenkins) (done bool, err error) {
+	seedJobIDs, err := s.getRemovedSeedJobIDs(jenkins)
+	if err != nil {
+		return false, err
+	}
+	if len(seedJobIDs) == 0 {
+		return true, nil
+	}
+
+	_, created, err := s.jenkinsClient.CreateOrUpdateJob(removeSeedJobsConfigXML, RemoveSeedJobsName)
+	if err != nil {
+		return false, err
+	}
+	if created {
+		s.logger.Info(fmt.Sprintf("'%s' job has been created", RemoveSeedJobsName))
+	}
+
+	parameters := map[string]string{
+		seedJobIDsParameterName:          strings.Join(seedJobIDs, ","),
+		removeGeneratedJobsParameterName: strconv.FormatBool(jenkins.Spec.SeedJobsRemovalPolicy == virtuslabv1alpha1.DeleteSeedJobsRemovalPolicy),
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(parameters[seedJobIDsParameterName]))
+	hash.Write([]byte(parameters[removeGeneratedJobsParameterName]))
+	encodedHash := base64.URLEncoding.EncodeToString(hash.Sum(nil))
+
+	jobsClient := jobs.New(s.jenkinsClient, s.k8sClient, s.logger)
+	done, err = jobsClient.EnsureBuildJob(RemoveSeedJobsName, encodedHash, parameters, jenkins, true)
+	if err != nil {
+		return false, err
+	}
+	if done {
+		s.logger.Info(fmt.Sprintf("Seed jobs '%s' have been removed", parameters[seedJobIDsParameterName]))
+	}
+	return done, nil
+}
+
+// getRemovedSeedJobIDs returns sorted IDs of seed jobs which exist in Jenkins but are not defined in Jenkins CR
+func (s *SeedJobs) getRemovedSeedJobIDs(jenkins *virtuslabv1alpha1.Jenkins) ([]string, error) {
+	jobNames, err := s.jenkinsClient.GetAllJobNames()
+	if err != nil {
+		return nil, err
+	}
+
+	definedSeedJobIDs := map[string]bool{}
+	for _, seedJob := range jenkins.Spec.SeedJobs {
+		definedSeedJobIDs[seedJob.ID] = true
+	}
+
+	removed := map[string]bool{}
+	for _, job := range jobNames {
+		for _, suffix := range []string{constants.SeedJobSuffix, constants.PipelineSeedJobSuffix} {
+			if !strings.HasSuffix(job.Name, "-"+suffix) {
+				continue
+			}
+			seedJobID := strings.TrimSuffix(job.Name, "-"+suffix)
+			if !definedSeedJobIDs[seedJobID] {
+				removed[seedJobID] = true
+			}
+		}
+	}
+
+	var removedSeedJobIDs []string
+	for seedJobID := range removed {
+		removedSeedJobIDs = append(removedSeedJobIDs, seedJobID)
+	}
+	sort.Strings(removedSeedJobIDs)
+
+	return removedSeedJobIDs, nil
+}
+
 // createJob is responsible for creating jenkins job which configures jenkins seed jobs and deploy keys
 func (s *SeedJobs) createJob() error {
 	_, created, err := s.jenkinsClient.CreateOrUpdateJob(seedJobConfigXML, ConfigureSeedJobsName)
@@ -78,55 +187,125 @@ func (s *SeedJobs) buildJobs(jenkins *virtuslabv1alpha1.Jenkins) (done bool, err
 	allDone := true
 	seedJobs := jenkins.Spec.SeedJobs
 	for _, seedJob := range seedJobs {
-		privateKey, err := s.privateKeyFromSecret(jenkins.Namespace, seedJob)
-		if err != nil {
-			return false, err
-		}
 		parameters := map[string]string{
 			deployKeyIDParameterName:      seedJob.ID,
-			privateKeyParameterName:       privateKey,
 			repositoryURLParameterName:    seedJob.RepositoryURL,
 			repositoryBranchParameterName: seedJob.RepositoryBranch,
 			targetsParameterName:          seedJob.Targets,
 			displayNameParameterName:      fmt.Sprintf("Seed Job from %s", seedJob.ID),
+			credentialTypeParameterName:   string(GetCredentialType(seedJob)),
+			pollSCMParameterName:          seedJob.Triggers.PollSCM,
+			gitHubPushParameterName:       strconv.FormatBool(seedJob.Triggers.GitHubPush),
+			gitLabPushParameterName:       strconv.FormatBool(seedJob.Triggers.GitLabPush),
+			bitbucketPushParameterName:    strconv.FormatBool(seedJob.Triggers.BitbucketPush),
+			seedJobTypeParameterName:      string(GetSeedJobType(seedJob)),
+			sandboxParameterName:          strconv.FormatBool(seedJob.Sandbox),
+			scriptSecurityParameterName:   strconv.FormatBool(jenkins.Spec.JobDSLScriptSecurity),
+		}
+		if err := s.setCredentialParameters(jenkins.Namespace, seedJob, parameters); err != nil {
+			return false, err
 		}
 
 		hash := sha256.New()
 		hash.Write([]byte(parameters[deployKeyIDParameterName]))
 		hash.Write([]byte(parameters[privateKeyParameterName]))
+		hash.Write([]byte(parameters[passphraseParameterName]))
 		hash.Write([]byte(parameters[repositoryURLParameterName]))
 		hash.Write([]byte(parameters[repositoryBranchParameterName]))
 		hash.Write([]byte(parameters[targetsParameterName]))
 		hash.Write([]byte(parameters[displayNameParameterName]))
+		hash.Write([]byte(parameters[credentialTypeParameterName]))
+		hash.Write([]byte(parameters[usernameParameterName]))
+		hash.Write([]byte(parameters[passwordParameterName]))
+		hash.Write([]byte(parameters[pollSCMParameterName]))
+		hash.Write([]byte(parameters[gitHubPushParameterName]))
+		hash.Write([]byte(parameters[gitLabPushParameterName]))
+		hash.Write([]byte(parameters[bitbucketPushParameterName]))
+		hash.Write([]byte(parameters[seedJobTypeParameterName]))
+		hash.Write([]byte(parameters[sandboxParameterName]))
+		hash.Write([]byte(parameters[scriptSecurityParameterName]))
 		encodedHash := base64.URLEncoding.EncodeToString(hash.Sum(nil))
 
 		jobsClient := jobs.New(s.jenkinsClient, s.k8sClient, s.logger)
-		done, err := jobsClient.EnsureBuildJob(ConfigureSeedJobsName, encodedHash, parameters, jenkins, true)
+		done, err := jobsClient.EnsureSharedBuildJob(ConfigureSeedJobsName, seedJob.ID, encodedHash, parameters, jenkins, true)
+		if err == jobs.ErrorBuildFailed || err == jobs.ErrorUnrecoverableBuildFailed {
+			if statusErr := s.updateConfigurationErrorInStatus(jenkins, seedJob.ID, encodedHash); statusErr != nil {
+				s.logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't update status of '%s' seed job: %s", seedJob.ID, statusErr))
+			}
+		}
 		if err != nil {
 			return false, err
 		}
 		if !done {
 			allDone = false
+			continue
+		}
+
+		// credential is configured by the seed job, the pipeline job is created by the operator
+		if GetSeedJobType(seedJob) != virtuslabv1alpha1.JobDSLSeedJobType {
+			if err := s.ensurePipelineJob(seedJob); err != nil {
+				return false, err
+			}
 		}
 	}
 	return allDone, nil
 }
 
-// privateKeyFromSecret it's utility function which extracts deploy key from the kubernetes secret
-func (s *SeedJobs) privateKeyFromSecret(namespace string, seedJob virtuslabv1alpha1.SeedJob) (string, error) {
+// GetCredentialType returns type of credential used to access seed job repository,
+// for backward compatibility SSH key is used when credential type is not set and private key is provided
+func GetCredentialType(seedJob virtuslabv1alpha1.SeedJob) virtuslabv1alpha1.SeedJobCredentialType {
+	if len(seedJob.CredentialType) > 0 {
+		return seedJob.CredentialType
+	}
 	if seedJob.PrivateKey.SecretKeyRef != nil {
-		deployKeySecret := &v1.Secret{}
-		namespaceName := types.NamespacedName{Namespace: namespace, Name: seedJob.PrivateKey.SecretKeyRef.Name}
-		err := s.k8sClient.Get(context.TODO(), namespaceName, deployKeySecret)
+		return virtuslabv1alpha1.SSHKeySeedJobCredentialType
+	}
+	return virtuslabv1alpha1.NoSeedJobCredentialType
+}
+
+// setCredentialParameters sets seed job parameters with credential read from kubernetes secrets
+func (s *SeedJobs) setCredentialParameters(namespace string, seedJob virtuslabv1alpha1.SeedJob, parameters map[string]string) error {
+	var err error
+	switch GetCredentialType(seedJob) {
+	case virtuslabv1alpha1.SSHKeySeedJobCredentialType:
+		parameters[privateKeyParameterName], err = s.valueFromSecret(namespace, seedJob.PrivateKey.SecretKeyRef)
+		if err != nil || len(seedJob.PrivateKey.PassphraseKey) == 0 {
+			return err
+		}
+		parameters[passphraseParameterName], err = s.valueFromSecret(namespace, &v1.SecretKeySelector{
+			LocalObjectReference: seedJob.PrivateKey.SecretKeyRef.LocalObjectReference,
+			Key:                  seedJob.PrivateKey.PassphraseKey,
+		})
+	case virtuslabv1alpha1.UsernamePasswordSeedJobCredentialType:
+		parameters[usernameParameterName], err = s.valueFromSecret(namespace, seedJob.UsernamePassword.UsernameSecretKeyRef)
 		if err != nil {
-			return "", err
+			return err
+		}
+		parameters[passwordParameterName], err = s.valueFromSecret(namespace, seedJob.UsernamePassword.PasswordSecretKeyRef)
+	case virtuslabv1alpha1.TokenSeedJobCredentialType:
+		parameters[usernameParameterName] = seedJob.Token.Username
+		if len(parameters[usernameParameterName]) == 0 {
+			parameters[usernameParameterName] = defaultTokenUsername
 		}
-		return string(deployKeySecret.Data[seedJob.PrivateKey.SecretKeyRef.Key]), nil
+		parameters[passwordParameterName], err = s.valueFromSecret(namespace, seedJob.Token.SecretKeyRef)
+	}
+	return err
+}
+
+// valueFromSecret it's utility function which extracts value from the kubernetes secret
+func (s *SeedJobs) valueFromSecret(namespace string, secretKeyRef *v1.SecretKeySelector) (string, error) {
+	if secretKeyRef == nil {
+		return "", nil
+	}
+	secret := &v1.Secret{}
+	namespaceName := types.NamespacedName{Namespace: namespace, Name: secretKeyRef.Name}
+	err := s.k8sClient.Get(context.TODO(), namespaceName, secret)
+	if err != nil {
+		return "", err
 	}
-	return "", nil
+	return string(secret.Data[secretKeyRef.Key]), nil
 }
 
-// FIXME(antoniaklja) use mask-password plugin for params.PRIVATE_KEY
 // seedJobConfigXML this is the XML representation of seed job
 var seedJobConfigXML = `
 <flow-definition plugin="workflow-job@2.30">
@@ -142,11 +321,16 @@ var seedJobConfigXML = `
           <defaultValue></defaultValue>
           <trim>false</trim>
         </hudson.model.StringParameterDefinition>
-        <hudson.model.StringParameterDefinition>
+        <hudson.model.PasswordParameterDefinition>
           <name>` + privateKeyParameterName + `</name>
           <description></description>
           <defaultValue></defaultValue>
-        </hudson.model.StringParameterDefinition>
+        </hudson.model.PasswordParameterDefinition>
+        <hudson.model.PasswordParameterDefinition>
+          <name>` + passphraseParameterName + `</name>
+          <description></description>
+          <defaultValue></defaultValue>
+        </hudson.model.PasswordParameterDefinition>
         <hudson.model.StringParameterDefinition>
           <name>` + repositoryURLParameterName + `</name>
           <description></description>
@@ -171,6 +355,65 @@ var seedJobConfigXML = `
           <defaultValue>cicd/jobs/*.jenkins</defaultValue>
           <trim>false</trim>
         </hudson.model.StringParameterDefinition>
+        <hudson.model.StringParameterDefinition>
+          <name>` + credentialTypeParameterName + `</name>
+          <description></description>
+          <defaultValue></defaultValue>
+          <trim>false</trim>
+        </hudson.model.StringParameterDefinition>
+        <hudson.model.StringParameterDefinition>
+          <name>` + usernameParameterName + `</name>
+          <description></description>
+          <defaultValue></defaultValue>
+          <trim>false</trim>
+        </hudson.model.StringParameterDefinition>
+        <hudson.model.PasswordParameterDefinition>
+          <name>` + passwordParameterName + `</name>
+          <description></description>
+          <defaultValue></defaultValue>
+        </hudson.model.PasswordParameterDefinition>
+        <hudson.model.StringParameterDefinition>
+          <name>` + seedJobTypeParameterName + `</name>
+          <description></description>
+          <defaultValue>` + string(virtuslabv1alpha1.JobDSLSeedJobType) + `</defaultValue>
+          <trim>false</trim>
+        </hudson.model.StringParameterDefinition>
+        <hudson.model.StringParameterDefinition>
+          <name>` + pollSCMParameterName + `</name>
+          <description></description>
+          <defaultValue></defaultValue>
+          <trim>false</trim>
+        </hudson.model.StringParameterDefinition>
+        <hudson.model.StringParameterDefinition>
+          <name>` + gitHubPushParameterName + `</name>
+          <description></description>
+          <defaultValue>false</defaultValue>
+          <trim>false</trim>
+        </hudson.model.StringParameterDefinition>
+        <hudson.model.StringParameterDefinition>
+          <name>` + gitLabPushParameterName + `</name>
+          <description></description>
+          <defaultValue>false</defaultValue>
+          <trim>false</trim>
+        </hudson.model.StringParameterDefinition>
+        <hudson.model.StringParameterDefinition>
+          <name>` + bitbucketPushParameterName + `</name>
+          <description></description>
+          <defaultValue>false</defaultValue>
+          <trim>false</trim>
+        </hudson.model.StringParameterDefinition>
+        <hudson.model.StringParameterDefinition>
+          <name>` + sandboxParameterName + `</name>
+          <description></description>
+          <defaultValue>false</defaultValue>
+          <trim>false</trim>
+        </hudson.model.StringParameterDefinition>
+        <hudson.model.StringParameterDefinition>
+          <name>` + scriptSecurityParameterName + `</name>
+          <description></description>
+          <defaultValue>false</defaultValue>
+          <trim>false</trim>
+        </hudson.model.StringParameterDefinition>
       </parameterDefinitions>
     </hudson.model.ParametersDefinitionProperty>
   </properties>
@@ -180,12 +423,15 @@ import com.cloudbees.jenkins.plugins.sshcredentials.impl.BasicSSHUserPrivateKey.
 import com.cloudbees.plugins.credentials.CredentialsScope
 import com.cloudbees.plugins.credentials.SystemCredentialsProvider
 import com.cloudbees.plugins.credentials.domains.Domain
+import com.cloudbees.plugins.credentials.impl.UsernamePasswordCredentialsImpl
 import hudson.model.FreeStyleProject
 import hudson.model.labels.LabelAtom
 import hudson.plugins.git.BranchSpec
 import hudson.plugins.git.GitSCM
 import hudson.plugins.git.SubmoduleConfig
 import hudson.plugins.git.extensions.impl.CloneOption
+import hudson.triggers.SCMTrigger
+import hudson.util.Secret
 import javaposse.jobdsl.plugin.ExecuteDslScripts
 import javaposse.jobdsl.plugin.LookupStrategy
 import javaposse.jobdsl.plugin.RemovedJobAction
@@ -196,26 +442,58 @@ import jenkins.model.GlobalConfiguration
 
 import static com.google.common.collect.Lists.newArrayList
 
-// https://javadoc.jenkins.io/plugin/ssh-credentials/com/cloudbees/jenkins/plugins/sshcredentials/impl/BasicSSHUserPrivateKey.html
-BasicSSHUserPrivateKey deployKeyPrivate = new BasicSSHUserPrivateKey(
-        CredentialsScope.GLOBAL,
-        &quot;${params.DEPLOY_KEY_ID}&quot;,
-        &quot;git&quot;,
-        new DirectEntryPrivateKeySource(&quot;${params.PRIVATE_KEY}&quot;),
-        &quot;&quot;,
-        &quot;${params.DEPLOY_KEY_ID}&quot;
-)
+def credential = null
+def credentialsId = null
+if (params.` + credentialTypeParameterName + ` == &quot;` + string(virtuslabv1alpha1.SSHKeySeedJobCredentialType) + `&quot;) {
+    // https://javadoc.jenkins.io/plugin/ssh-credentials/com/cloudbees/jenkins/plugins/sshcredentials/impl/BasicSSHUserPrivateKey.html
+    credential = new BasicSSHUserPrivateKey(
+            CredentialsScope.GLOBAL,
+            &quot;${params.DEPLOY_KEY_ID}&quot;,
+            &quot;git&quot;,
+            new DirectEntryPrivateKeySource(Secret.toString(params.PRIVATE_KEY)),
+            Secret.toString(params.PASSPHRASE),
+            &quot;${params.DEPLOY_KEY_ID}&quot;
+    )
+} else if (params.` + credentialTypeParameterName + ` == &quot;` + string(virtuslabv1alpha1.UsernamePasswordSeedJobCredentialType) + `&quot; ||
+        params.` + credentialTypeParameterName + ` == &quot;` + string(virtuslabv1alpha1.TokenSeedJobCredentialType) + `&quot;) {
+    // https://javadoc.jenkins.io/plugin/credentials/com/cloudbees/plugins/credentials/impl/UsernamePasswordCredentialsImpl.html
+    credential = new UsernamePasswordCredentialsImpl(
+            CredentialsScope.GLOBAL,
+            &quot;${params.DEPLOY_KEY_ID}&quot;,
+            &quot;${params.DEPLOY_KEY_ID}&quot;,
+            &quot;${params.USERNAME}&quot;,
+            Secret.toString(params.PASSWORD)
+    )
+}
 
-// https://javadoc.jenkins.io/plugin/credentials/index.html?com/cloudbees/plugins/credentials/SystemCredentialsProvider.html
-SystemCredentialsProvider.getInstance().getStore().addCredentials(Domain.global(), deployKeyPrivate)
+if (credential != null) {
+    credentialsId = params.DEPLOY_KEY_ID
+    // https://javadoc.jenkins.io/plugin/credentials/index.html?com/cloudbees/plugins/credentials/SystemCredentialsProvider.html
+    def store = SystemCredentialsProvider.getInstance().getStore()
+    def existingCredential = null
+    for (storedCredential in store.getCredentials(Domain.global())) {
+        if (storedCredential.id == credentialsId) {
+            existingCredential = storedCredential
+        }
+    }
+    if (existingCredential != null) {
+        store.updateCredentials(Domain.global(), existingCredential, credential)
+    } else {
+        store.addCredentials(Domain.global(), credential)
+    }
+}
+
+if (params.` + seedJobTypeParameterName + ` != &quot;` + string(virtuslabv1alpha1.JobDSLSeedJobType) + `&quot;) {
+    // Multibranch Pipeline jobs and Organization Folders are created by the operator
+    return
+}
 
 Jenkins jenkins = Jenkins.instance
 
 def jobDslSeedName = &quot;${params.DEPLOY_KEY_ID}-` + constants.SeedJobSuffix + `&quot;
-def jobDslDeployKeyName = &quot;${params.DEPLOY_KEY_ID}&quot;
 def jobRef = jenkins.getItem(jobDslSeedName)
 
-def repoList = GitSCM.createRepoList(&quot;${params.REPOSITORY_URL}&quot;, jobDslDeployKeyName)
+def repoList = GitSCM.createRepoList(&quot;${params.REPOSITORY_URL}&quot;, credentialsId)
 def gitExtensions = [new CloneOption(true, true, &quot;&quot;, 10)]
 def scm = new GitSCM(
         repoList,
@@ -229,7 +507,7 @@ def scm = new GitSCM(
 
 def executeDslScripts = new ExecuteDslScripts()
 executeDslScripts.setTargets(&quot;${params.TARGETS}&quot;)
-executeDslScripts.setSandbox(false)
+executeDslScripts.setSandbox(params.` + sandboxParameterName + ` == &quot;true&quot;)
 executeDslScripts.setRemovedJobAction(RemovedJobAction.DELETE)
 executeDslScripts.setRemovedViewAction(RemovedViewAction.DELETE)
 executeDslScripts.setLookupStrategy(LookupStrategy.SEED_JOB)
@@ -244,8 +522,32 @@ jobRef.setDisplayName(&quot;${params.SEED_JOB_DISPLAY_NAME}&quot;)
 jobRef.setScm(scm)
 jobRef.setAssignedLabel(new LabelAtom(&quot;master&quot;))
 
-// disable Job DSL script approval
-GlobalConfiguration.all().get(GlobalJobDslSecurityConfiguration.class).useScriptSecurity=false
+// triggers are loaded by name, the plugins providing push triggers are optional
+def triggers = []
+if (params.` + pollSCMParameterName + ` != &quot;&quot;) {
+    triggers.add(new SCMTrigger(params.` + pollSCMParameterName + `))
+}
+if (params.` + gitHubPushParameterName + ` == &quot;true&quot;) {
+    triggers.add(jenkins.pluginManager.uberClassLoader.loadClass(&quot;com.cloudbees.jenkins.GitHubPushTrigger&quot;).newInstance())
+}
+if (params.` + gitLabPushParameterName + ` == &quot;true&quot;) {
+    def gitLabPushTrigger = jenkins.pluginManager.uberClassLoader.loadClass(&quot;com.dabsquared.gitlabjenkins.GitLabPushTrigger&quot;).newInstance()
+    gitLabPushTrigger.setTriggerOnPush(true)
+    triggers.add(gitLabPushTrigger)
+}
+if (params.` + bitbucketPushParameterName + ` == &quot;true&quot;) {
+    triggers.add(jenkins.pluginManager.uberClassLoader.loadClass(&quot;com.cloudbees.jenkins.plugins.BitBucketTrigger&quot;).newInstance())
+}
+for (existingTrigger in new ArrayList(jobRef.getTriggers().values())) {
+    jobRef.removeTrigger(existingTrigger.getDescriptor())
+}
+for (trigger in triggers) {
+    jobRef.addTrigger(trigger)
+    trigger.start(jobRef, true)
+}
+
+// Job DSL script approval is enabled when at least one seed job runs in sandbox
+GlobalConfiguration.all().get(GlobalJobDslSecurityConfiguration.class).useScriptSecurity=(params.` + scriptSecurityParameterName + ` == &quot;true&quot;)
 GlobalConfiguration.all().get(GlobalJobDslSecurityConfiguration.class).save()
 jenkins.getQueue().schedule(jobRef)
 </script>
@@ -255,3 +557,75 @@ jenkins.getQueue().schedule(jobRef)
   <disabled>false</disabled>
 </flow-definition>
 `
+
+// removeSeedJobsConfigXML this is the XML representation of job which removes seed jobs, their credentials and generated jobs
+var removeSeedJobsConfigXML = `
+<flow-definition plugin="workflow-job@2.30">
+  <actions/>
+  <description>Remove Seed Jobs</description>
+  <keepDependencies>false</keepDependencies>
+  <properties>
+    <hudson.model.ParametersDefinitionProperty>
+      <parameterDefinitions>
+        <hudson.model.StringParameterDefinition>
+          <name>` + seedJobIDsParameterName + `</name>
+          <description></description>
+          <defaultValue></defaultValue>
+          <trim>false</trim>
+        </hudson.model.StringParameterDefinition>
+        <hudson.model.StringParameterDefinition>
+          <name>` + removeGeneratedJobsParameterName + `</name>
+          <description></description>
+          <defaultValue>false</defaultValue>
+          <trim>false</trim>
+        </hudson.model.StringParameterDefinition>
+      </parameterDefinitions>
+    </hudson.model.ParametersDefinitionProperty>
+  </properties>
+  <definition class="org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition" plugin="workflow-cps@2.61">
+    <script>import com.cloudbees.plugins.credentials.SystemCredentialsProvider
+import com.cloudbees.plugins.credentials.domains.Domain
+import javaposse.jobdsl.plugin.actions.GeneratedJobsAction
+import jenkins.model.Jenkins
+
+Jenkins jenkins = Jenkins.instance
+def store = SystemCredentialsProvider.getInstance().getStore()
+
+for (seedJobId in params.` + seedJobIDsParameterName + `.tokenize(&apos;,&apos;)) {
+    def seedJob = jenkins.getItem(&quot;${seedJobId}-` + constants.SeedJobSuffix + `&quot;)
+    if (seedJob != null) {
+        if (params.` + removeGeneratedJobsParameterName + ` == &apos;true&apos;) {
+            def generatedJobsAction = seedJob.getAction(GeneratedJobsAction)
+            if (generatedJobsAction != null) {
+                for (generatedJob in generatedJobsAction.getItems()) {
+                    println &quot;Deleting job &apos;${generatedJob.fullName}&apos; generated by &apos;${seedJob.name}&apos;&quot;
+                    generatedJob.delete()
+                }
+            }
+        }
+        println &quot;Deleting seed job &apos;${seedJob.name}&apos;&quot;
+        seedJob.delete()
+    }
+
+    // Multibranch Pipeline job or Organization Folder with all its branches and repositories
+    def pipelineJob = jenkins.getItem(&quot;${seedJobId}-` + constants.PipelineSeedJobSuffix + `&quot;)
+    if (pipelineJob != null) {
+        println &quot;Deleting seed job &apos;${pipelineJob.name}&apos;&quot;
+        pipelineJob.delete()
+    }
+
+    // credential created by ` + ConfigureSeedJobsName + ` has the same ID and description as seed job
+    for (credential in new ArrayList(store.getCredentials(Domain.global()))) {
+        if (credential.id == seedJobId &amp;&amp; credential.description == seedJobId) {
+            println &quot;Deleting credential &apos;${credential.id}&apos;&quot;
+            store.removeCredentials(Domain.global(), credential)
+        }
+    }
+}
+</script>
+    <sandbox>false</sandbox>
+  </definition>
+  <triggers/>
+  <disabled>false</disabled>
+</flow-definition>
+`
@@ -2,7 +2,10 @@ package seedjobs
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
@@ -36,6 +39,7 @@ func TestEnsureSeedJobs(t *testing.T) {
 	err = fakeClient.Create(ctx, jenkins)
 	assert.NoError(t, err)
 	buildNumber := int64(1)
+	lastCommitSHA := "5d4d4ba9e6ba1d0e6ba9b4c4a47d2bcb6f6a4a9c"
 
 	for reconcileAttempt := 1; reconcileAttempt <= 2; reconcileAttempt++ {
 		logger.Info(fmt.Sprintf("Reconcile attempt #%d", reconcileAttempt))
@@ -79,6 +83,36 @@ func TestEnsureSeedJobs(t *testing.T) {
 						Result: string(virtuslabv1alpha1.BuildSuccessStatus),
 					},
 				}, nil)
+
+			jenkinsClient.
+				EXPECT().
+				GetJob("jenkins-operator-e2e-job-dsl-seed").
+				Return(&gojenkins.Job{
+					Raw: &gojenkins.JobResponse{
+						LastCompletedBuild: gojenkins.JobBuild{Number: 3},
+					},
+				}, nil)
+
+			seedJobBuild := &gojenkins.BuildResponse{}
+			err = json.Unmarshal([]byte(`{"number":3,"result":"SUCCESS","timestamp":1546300800000,`+
+				`"actions":[{},{"lastBuiltRevision":{"SHA1":"`+lastCommitSHA+`"}}]}`), seedJobBuild)
+			assert.NoError(t, err)
+			jenkinsClient.
+				EXPECT().
+				GetBuild("jenkins-operator-e2e-job-dsl-seed", int64(3)).
+				Return(&gojenkins.Build{
+					Raw:     seedJobBuild,
+					Base:    "/job/jenkins-operator-e2e-job-dsl-seed/3",
+					Jenkins: consoleOutputServer(t, seedJobConsoleOutput),
+				}, nil)
+
+			jenkinsClient.
+				EXPECT().
+				GetAllJobNames().
+				Return([]gojenkins.InnerJob{
+					{Name: ConfigureSeedJobsName},
+					{Name: "jenkins-operator-e2e-job-dsl-seed"},
+				}, nil)
 		}
 
 		done, err := seedJobs.EnsureSeedJobs(jenkins)
@@ -94,7 +128,7 @@ func TestEnsureSeedJobs(t *testing.T) {
 		assert.NotNil(t, build.CreateTime)
 		assert.NotEmpty(t, build.Hash)
 		assert.NotNil(t, build.LastUpdateTime)
-		assert.Equal(t, 0, build.Retires)
+		assert.Equal(t, 0, build.Retries)
 
 		// first run - should create job and schedule build
 		if reconcileAttempt == 1 {
@@ -106,11 +140,112 @@ func TestEnsureSeedJobs(t *testing.T) {
 		if reconcileAttempt == 2 {
 			assert.True(t, done)
 			assert.Equal(t, string(virtuslabv1alpha1.BuildSuccessStatus), string(build.Status))
+			assert.Equal(t, 1, len(jenkins.Status.SeedJobs))
+			seedJobStatus := jenkins.Status.SeedJobs[0]
+			assert.Equal(t, "jenkins-operator-e2e", seedJobStatus.ID)
+			assert.Equal(t, lastCommitSHA, seedJobStatus.LastCommitSHA)
+			assert.Equal(t, int64(3), seedJobStatus.BuildNumber)
+			assert.Equal(t, virtuslabv1alpha1.BuildSuccessStatus, seedJobStatus.Result)
+			assert.Equal(t, int64(1546300800), seedJobStatus.LastRunTime.Unix())
+			assert.Equal(t, 2, seedJobStatus.GeneratedJobCount)
+			assert.Empty(t, seedJobStatus.ErrorMessage)
 		}
 
 	}
 }
 
+var seedJobConsoleOutput = `Started by user jenkins-operator
+Processing DSL script cicd/jobs/build.jenkins
+Added items:
+    GeneratedJob{name='build'}
+Processing DSL script cicd/jobs/e2e.jenkins
+Existing items:
+    GeneratedJob{name='k8s-e2e'}
+Unreferenced items:
+    GeneratedJob{name='removed'}
+Finished: SUCCESS
+`
+
+func consoleOutputServer(t *testing.T, consoleOutput string) *gojenkins.Jenkins {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(consoleOutput))
+		assert.NoError(t, err)
+	}))
+	return &gojenkins.Jenkins{
+		Server:    server.URL,
+		Requester: &gojenkins.Requester{Base: server.URL, Client: server.Client()},
+	}
+}
+
+func TestGetGeneratedJobCount(t *testing.T) {
+	assert.Equal(t, 0, getGeneratedJobCount(""))
+	assert.Equal(t, 2, getGeneratedJobCount(seedJobConsoleOutput))
+	assert.Equal(t, 1, getGeneratedJobCount(`Processing DSL script cicd/jobs/build.jenkins
+Added items:
+    GeneratedJob{name='build'}
+Processing DSL script cicd/jobs/build-copy.jenkins
+Existing items:
+    GeneratedJob{name='build'}
+Added views:
+    GeneratedView{name='builds'}
+`))
+}
+
+func TestGetRemovedSeedJobIDs(t *testing.T) {
+	data := []struct {
+		description string
+		jobNames    []gojenkins.InnerJob
+		expected    []string
+	}{
+		{
+			description: "no seed jobs in Jenkins",
+			jobNames:    []gojenkins.InnerJob{{Name: ConfigureSeedJobsName}},
+		},
+		{
+			description: "all seed jobs defined in Jenkins CR",
+			jobNames:    []gojenkins.InnerJob{{Name: ConfigureSeedJobsName}, {Name: "jenkins-operator-e2e-job-dsl-seed"}},
+		},
+		{
+			description: "seed jobs removed from Jenkins CR",
+			jobNames: []gojenkins.InnerJob{
+				{Name: "removed-b-job-dsl-seed"},
+				{Name: "jenkins-operator-e2e-job-dsl-seed"},
+				{Name: "removed-a-job-dsl-seed"},
+				{Name: "generated-job"},
+			},
+			expected: []string{"removed-a", "removed-b"},
+		},
+		{
+			description: "pipeline seed jobs removed from Jenkins CR",
+			jobNames: []gojenkins.InnerJob{
+				{Name: "removed-multibranch-pipeline-seed"},
+				{Name: "jenkins-operator-e2e-job-dsl-seed"},
+				{Name: "jenkins-operator-e2e-pipeline-seed"},
+				{Name: "removed-a-job-dsl-seed"},
+				{Name: "removed-a-pipeline-seed"},
+				{Name: "removed-multibranch"},
+			},
+			expected: []string{"removed-a", "removed-multibranch"},
+		},
+	}
+
+	for _, testingData := range data {
+		t.Run(testingData.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			jenkinsClient := client.NewMockJenkins(ctrl)
+			jenkinsClient.EXPECT().GetAllJobNames().Return(testingData.jobNames, nil)
+			seedJobs := New(jenkinsClient, fake.NewFakeClient(), logf.ZapLogger(false))
+
+			seedJobIDs, err := seedJobs.getRemovedSeedJobIDs(jenkinsCustomResource())
+
+			assert.NoError(t, err)
+			assert.Equal(t, testingData.expected, seedJobIDs)
+		})
+	}
+}
+
 func jenkinsCustomResource() *virtuslabv1alpha1.Jenkins {
 	return &virtuslabv1alpha1.Jenkins{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,155 @@
+package seedjobs
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/jobs"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/status"
+	"github.com/VirtusLab/jenkins-operator/pkg/log"
+
+	"github.com/bndr/gojenkins"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// generatedJobRegexp matches job printed by Job DSL plugin in console output, for example GeneratedJob{name='build'}
+var generatedJobRegexp = regexp.MustCompile(`^GeneratedJob\{name='(.+)'}$`)
+
+// UpdateStatus updates Jenkins.Status.SeedJobs with the last completed build of every Job DSL seed job
+func (s *SeedJobs) UpdateStatus(jenkins *virtuslabv1alpha1.Jenkins) error {
+	var seedJobsStatus []virtuslabv1alpha1.SeedJobStatus
+	for _, seedJob := range jenkins.Spec.SeedJobs {
+		status := virtuslabv1alpha1.SeedJobStatus{ID: seedJob.ID}
+		if GetSeedJobType(seedJob) == virtuslabv1alpha1.JobDSLSeedJobType {
+			currentStatus := getSeedJobStatus(jenkins, seedJob.ID)
+			newStatus, err := s.getSeedJobBuildStatus(seedJob, currentStatus)
+			if err != nil {
+				s.logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't get status of '%s' seed job: %s", seedJob.ID, err))
+				newStatus = currentStatus
+			}
+			status = newStatus
+		}
+		seedJobsStatus = append(seedJobsStatus, status)
+	}
+
+	if reflect.DeepEqual(jenkins.Status.SeedJobs, seedJobsStatus) {
+		return nil
+	}
+	jenkins.Status.SeedJobs = seedJobsStatus
+	return status.Update(s.k8sClient, jenkins)
+}
+
+// getSeedJobBuildStatus returns status of the last completed build of Job DSL seed job,
+// the build is fetched from Jenkins only when it's not reflected in the current status yet
+func (s *SeedJobs) getSeedJobBuildStatus(seedJob virtuslabv1alpha1.SeedJob, status virtuslabv1alpha1.SeedJobStatus) (virtuslabv1alpha1.SeedJobStatus, error) {
+	jobName := fmt.Sprintf("%s-%s", seedJob.ID, constants.SeedJobSuffix)
+	job, err := s.jenkinsClient.GetJob(jobName)
+	if err != nil {
+		return status, err
+	}
+	if job.Raw == nil || job.Raw.LastCompletedBuild.Number == 0 || job.Raw.LastCompletedBuild.Number == status.BuildNumber {
+		return status, nil
+	}
+
+	build, err := s.jenkinsClient.GetBuild(jobName, job.Raw.LastCompletedBuild.Number)
+	if err != nil {
+		return status, err
+	}
+
+	status.BuildNumber = build.GetBuildNumber()
+	status.Result = virtuslabv1alpha1.BuildStatus(strings.ToLower(build.GetResult()))
+	status.LastRunTime = &metav1.Time{Time: build.GetTimestamp().Truncate(time.Second)}
+	if lastCommitSHA := getLastCommitSHA(build); len(lastCommitSHA) > 0 {
+		status.LastCommitSHA = lastCommitSHA
+	}
+
+	consoleOutput := build.GetConsoleOutput()
+	status.GeneratedJobCount = getGeneratedJobCount(consoleOutput)
+	status.ErrorMessage = ""
+	if status.Result != virtuslabv1alpha1.BuildSuccessStatus {
+		status.ErrorMessage = jobs.GetErrorMessage(consoleOutput)
+	}
+
+	return status, nil
+}
+
+// updateConfigurationErrorInStatus saves error of the failed build which configures seed job in Jenkins.Status.SeedJobs
+func (s *SeedJobs) updateConfigurationErrorInStatus(jenkins *virtuslabv1alpha1.Jenkins, seedJobID, hash string) error {
+	var number int64
+	for _, build := range jenkins.Status.Builds {
+		if build.JobName == ConfigureSeedJobsName && build.Hash == hash {
+			number = build.Number
+		}
+	}
+	if number == 0 {
+		return nil
+	}
+
+	build, err := s.jenkinsClient.GetBuild(ConfigureSeedJobsName, number)
+	if err != nil {
+		return err
+	}
+
+	seedJobStatus := getSeedJobStatus(jenkins, seedJobID)
+	seedJobStatus.Result = virtuslabv1alpha1.BuildFailureStatus
+	seedJobStatus.LastRunTime = &metav1.Time{Time: build.GetTimestamp().Truncate(time.Second)}
+	seedJobStatus.ErrorMessage = fmt.Sprintf("couldn't configure seed job: %s", jobs.GetErrorMessage(build.GetConsoleOutput()))
+
+	found := false
+	for i, currentStatus := range jenkins.Status.SeedJobs {
+		if currentStatus.ID == seedJobID {
+			if reflect.DeepEqual(currentStatus, seedJobStatus) {
+				return nil
+			}
+			jenkins.Status.SeedJobs[i] = seedJobStatus
+			found = true
+		}
+	}
+	if !found {
+		jenkins.Status.SeedJobs = append(jenkins.Status.SeedJobs, seedJobStatus)
+	}
+	return status.Update(s.k8sClient, jenkins)
+}
+
+func getSeedJobStatus(jenkins *virtuslabv1alpha1.Jenkins, seedJobID string) virtuslabv1alpha1.SeedJobStatus {
+	for _, status := range jenkins.Status.SeedJobs {
+		if status.ID == seedJobID {
+			return *status.DeepCopy()
+		}
+	}
+	return virtuslabv1alpha1.SeedJobStatus{ID: seedJobID}
+}
+
+// getLastCommitSHA returns SHA of commit checked out by the build
+func getLastCommitSHA(build *gojenkins.Build) string {
+	for _, action := range build.Raw.Actions {
+		if len(action.LastBuiltRevision.SHA1) > 0 {
+			return action.LastBuiltRevision.SHA1
+		}
+	}
+	return ""
+}
+
+// getGeneratedJobCount returns number of jobs added or updated by Job DSL scripts
+func getGeneratedJobCount(consoleOutput string) int {
+	generatedJobs := map[string]bool{}
+	section := ""
+	for _, line := range strings.Split(consoleOutput, "\n") {
+		if len(line) > 0 && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			section = strings.TrimSpace(line)
+			continue
+		}
+		if section != "Added items:" && section != "Existing items:" {
+			continue
+		}
+		if match := generatedJobRegexp.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			generatedJobs[match[1]] = true
+		}
+	}
+	return len(generatedJobs)
+}
@@ -1,100 +1,641 @@
 package user
 
 import (
+	"bytes"
 	"context"
+	"crypto/dsa"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/backup"
-	"github.com/VirtusLab/jenkins-operator/pkg/log"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/base/resources"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/user/credentials"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/user/seedjobs"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/plugins"
 
+	"golang.org/x/crypto/ssh"
 	"k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
-// Validate validates Jenkins CR Spec section
-func (r *ReconcileUserConfiguration) Validate(jenkins *virtuslabv1alpha1.Jenkins) (bool, error) {
-	valid, err := r.validateSeedJobs(jenkins)
-	if !valid || err != nil {
-		return valid, err
+const (
+	gitHubPluginName    = "github"
+	gitLabPluginName    = "gitlab-plugin"
+	bitbucketPluginName = "bitbucket"
+
+	multibranchPluginName = "workflow-multibranch"
+
+	openSSHPrivateKeyType  = "OPENSSH PRIVATE KEY"
+	openSSHPrivateKeyMagic = "openssh-key-v1\x00"
+)
+
+var organizationFolderPluginNames = map[virtuslabv1alpha1.OrganizationProvider]string{
+	virtuslabv1alpha1.GitHubOrganizationProvider:    "github-branch-source",
+	virtuslabv1alpha1.BitbucketOrganizationProvider: "cloudbees-bitbucket-branch-source",
+}
+
+var credentialIDRegexp = regexp.MustCompile(`^[a-zA-Z0-9][-_a-zA-Z0-9]*$`)
+
+var cronAliases = map[string]bool{
+	"@yearly":   true,
+	"@annually": true,
+	"@monthly":  true,
+	"@weekly":   true,
+	"@daily":    true,
+	"@midnight": true,
+	"@hourly":   true,
+}
+
+// Validate validates Jenkins CR Spec section, it returns errors of invalid fields
+func (r *ReconcileUserConfiguration) Validate(jenkins *virtuslabv1alpha1.Jenkins) (field.ErrorList, error) {
+	allErrs := field.ErrorList{}
+	for _, validate := range []func(*virtuslabv1alpha1.Jenkins) (field.ErrorList, error){
+		r.validateSeedJobs,
+		r.validateCredentials,
+		r.validateConfigurationAsCode,
+		r.validateUserConfigurationSources,
+		r.validateUserConfigurationSecrets,
+	} {
+		errs, err := validate(jenkins)
+		if err != nil {
+			return nil, err
+		}
+		allErrs = append(allErrs, errs...)
 	}
 
 	backupProvider, err := backup.GetBackupProvider(r.jenkins.Spec.Backup)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+	backupErrs, err := backupProvider.ValidateForUserPhase(r.k8sClient, *r.jenkins)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(allErrs, backupErrs...), nil
+}
+
+func (r *ReconcileUserConfiguration) validateSeedJobs(jenkins *virtuslabv1alpha1.Jenkins) (field.ErrorList, error) {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+	if len(jenkins.Spec.SeedJobsRemovalPolicy) > 0 && !isSeedJobsRemovalPolicyAllowed(jenkins.Spec.SeedJobsRemovalPolicy) {
+		var policies []string
+		for _, policy := range virtuslabv1alpha1.AllowedSeedJobsRemovalPolicies {
+			policies = append(policies, string(policy))
+		}
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("seedJobsRemovalPolicy"), jenkins.Spec.SeedJobsRemovalPolicy, policies))
+	}
+	ids := map[string]bool{}
+	for index, seedJob := range jenkins.Spec.SeedJobs {
+		seedJobPath := specPath.Child("seedJobs").Index(index)
+
+		// validate seed job id is not empty
+		if len(seedJob.ID) == 0 {
+			allErrs = append(allErrs, field.Required(seedJobPath.Child("id"), "seed job id can't be empty"))
+		} else if ids[seedJob.ID] {
+			allErrs = append(allErrs, field.Duplicate(seedJobPath.Child("id"), seedJob.ID))
+		}
+		ids[seedJob.ID] = true
+
+		seedJobType := seedjobs.GetSeedJobType(seedJob)
+		if !isSeedJobTypeAllowed(seedJobType) {
+			var seedJobTypes []string
+			for _, allowedType := range virtuslabv1alpha1.AllowedSeedJobTypes {
+				seedJobTypes = append(seedJobTypes, string(allowedType))
+			}
+			allErrs = append(allErrs, field.NotSupported(seedJobPath.Child("type"), seedJobType, seedJobTypes))
+			continue
+		}
+
+		allErrs = append(allErrs, validateSeedJobType(jenkins, seedJob, seedJobPath)...)
+
+		// sandbox is ignored by Job DSL plugin unless script security is enabled globally
+		if seedJob.Sandbox && !jenkins.Spec.JobDSLScriptSecurity {
+			allErrs = append(allErrs, field.Invalid(seedJobPath.Child("sandbox"), seedJob.Sandbox,
+				"sandbox requires spec.jobDslScriptSecurity to be enabled"))
+		}
+
+		credentialType := seedjobs.GetCredentialType(seedJob)
+		if !isSeedJobCredentialTypeAllowed(credentialType) {
+			var credentialTypes []string
+			for _, allowedType := range virtuslabv1alpha1.AllowedSeedJobCredentialTypes {
+				credentialTypes = append(credentialTypes, string(allowedType))
+			}
+			allErrs = append(allErrs, field.NotSupported(seedJobPath.Child("credentialType"), credentialType, credentialTypes))
+			continue
+		}
+
+		// validate repository url match credential type, organization folder scans repositories through Git server API
+		if seedJobType == virtuslabv1alpha1.OrganizationFolderSeedJobType {
+			if credentialType == virtuslabv1alpha1.SSHKeySeedJobCredentialType {
+				allErrs = append(allErrs, field.Invalid(seedJobPath.Child("credentialType"), credentialType,
+					"organization folder requires username and password or token credential"))
+			}
+		} else if isSSHRepositoryURL(seedJob.RepositoryURL) && credentialType != virtuslabv1alpha1.SSHKeySeedJobCredentialType {
+			allErrs = append(allErrs, field.Required(seedJobPath.Child("privateKey"), "private key can't be empty while using ssh repository url"))
+		} else if !isHTTPRepositoryURL(seedJob.RepositoryURL) && (credentialType == virtuslabv1alpha1.UsernamePasswordSeedJobCredentialType ||
+			credentialType == virtuslabv1alpha1.TokenSeedJobCredentialType) {
+			allErrs = append(allErrs, field.Invalid(seedJobPath.Child("repositoryUrl"), seedJob.RepositoryURL,
+				fmt.Sprintf("credential type '%s' can be used only with http or https repository url", credentialType)))
+		}
+
+		var errs field.ErrorList
+		var err error
+		switch credentialType {
+		case virtuslabv1alpha1.SSHKeySeedJobCredentialType:
+			errs, err = r.validateSeedJobPrivateKey(jenkins.Namespace, seedJob, seedJobPath.Child("privateKey"))
+		case virtuslabv1alpha1.UsernamePasswordSeedJobCredentialType:
+			usernamePasswordPath := seedJobPath.Child("usernamePassword")
+			errs, err = r.validateSeedJobSecretValue(jenkins.Namespace, seedJob.UsernamePassword.UsernameSecretKeyRef, usernamePasswordPath.Child("usernameSecretKeyRef"))
+			if err == nil {
+				var passwordErrs field.ErrorList
+				passwordErrs, err = r.validateSeedJobSecretValue(jenkins.Namespace, seedJob.UsernamePassword.PasswordSecretKeyRef, usernamePasswordPath.Child("passwordSecretKeyRef"))
+				errs = append(errs, passwordErrs...)
+			}
+		case virtuslabv1alpha1.TokenSeedJobCredentialType:
+			errs, err = r.validateSeedJobSecretValue(jenkins.Namespace, seedJob.Token.SecretKeyRef, seedJobPath.Child("token", "secretKeyRef"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		allErrs = append(allErrs, errs...)
+	}
+	return allErrs, nil
+}
+
+func (r *ReconcileUserConfiguration) validateSeedJobPrivateKey(namespace string, seedJob virtuslabv1alpha1.SeedJob, fldPath *field.Path) (field.ErrorList, error) {
+	secretKeyRefPath := fldPath.Child("secretKeyRef")
+	if seedJob.PrivateKey.SecretKeyRef == nil {
+		return field.ErrorList{field.Required(secretKeyRefPath, "private key can't be empty while using ssh key credential type")}, nil
+	}
+
+	deployKeySecret := &v1.Secret{}
+	namespaceName := types.NamespacedName{Namespace: namespace, Name: seedJob.PrivateKey.SecretKeyRef.Name}
+	err := r.k8sClient.Get(context.TODO(), namespaceName, deployKeySecret)
+	if err != nil && apierrors.IsNotFound(err) {
+		return field.ErrorList{field.NotFound(secretKeyRefPath.Child("name"), seedJob.PrivateKey.SecretKeyRef.Name)}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	privateKey := string(deployKeySecret.Data[seedJob.PrivateKey.SecretKeyRef.Key])
+	if privateKey == "" {
+		return field.ErrorList{field.Invalid(secretKeyRefPath.Child("key"), seedJob.PrivateKey.SecretKeyRef.Key, "private key is empty")}, nil
+	}
+
+	passphrase := ""
+	if len(seedJob.PrivateKey.PassphraseKey) > 0 {
+		passphrase = string(deployKeySecret.Data[seedJob.PrivateKey.PassphraseKey])
+		if passphrase == "" {
+			return field.ErrorList{field.Invalid(fldPath.Child("passphraseKey"), seedJob.PrivateKey.PassphraseKey, "passphrase is empty")}, nil
+		}
+	}
+
+	if err := validatePrivateKey(privateKey, passphrase); err != nil {
+		return field.ErrorList{field.Invalid(secretKeyRefPath, seedJob.PrivateKey.SecretKeyRef.Name,
+			fmt.Sprintf("private key is invalid: %s", err))}, nil
+	}
+
+	return nil, nil
+}
+
+func (r *ReconcileUserConfiguration) validateSeedJobSecretValue(namespace string, secretKeyRef *v1.SecretKeySelector, fldPath *field.Path) (field.ErrorList, error) {
+	if secretKeyRef == nil {
+		return field.ErrorList{field.Required(fldPath, "secret key reference can't be empty")}, nil
+	}
+
+	secret := &v1.Secret{}
+	err := r.k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: secretKeyRef.Name}, secret)
+	if err != nil && apierrors.IsNotFound(err) {
+		return field.ErrorList{field.NotFound(fldPath.Child("name"), secretKeyRef.Name)}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if len(secret.Data[secretKeyRef.Key]) == 0 {
+		return field.ErrorList{field.Invalid(fldPath.Child("key"), secretKeyRef.Key, "secret value is empty")}, nil
+	}
+
+	return nil, nil
+}
+
+func isSeedJobCredentialTypeAllowed(credentialType virtuslabv1alpha1.SeedJobCredentialType) bool {
+	for _, allowedType := range virtuslabv1alpha1.AllowedSeedJobCredentialTypes {
+		if allowedType == credentialType {
+			return true
+		}
+	}
+	return false
+}
+
+func validateSeedJobType(jenkins *virtuslabv1alpha1.Jenkins, seedJob virtuslabv1alpha1.SeedJob, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch seedjobs.GetSeedJobType(seedJob) {
+	case virtuslabv1alpha1.JobDSLSeedJobType:
+		if len(seedJob.RepositoryURL) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("repositoryUrl"), "repository url can't be empty for jobDsl seed job"))
+		}
+		return append(allErrs, validateSeedJobTriggers(jenkins, seedJob, fldPath.Child("triggers"))...)
+	case virtuslabv1alpha1.MultibranchSeedJobType:
+		if len(seedJob.RepositoryURL) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("repositoryUrl"), "repository url can't be empty for multibranch seed job"))
+		}
+		if !plugins.IsConfigured(jenkins.Spec.Master.Plugins, multibranchPluginName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), seedJob.Type,
+				fmt.Sprintf("multibranch seed job requires '%s' plugin in spec.master.plugins", multibranchPluginName)))
+		}
+	case virtuslabv1alpha1.OrganizationFolderSeedJobType:
+		organizationFolder := seedJob.OrganizationFolder
+		organizationFolderPath := fldPath.Child("organizationFolder")
+		if len(organizationFolder.Owner) == 0 {
+			allErrs = append(allErrs, field.Required(organizationFolderPath.Child("owner"), "owner can't be empty for organization folder seed job"))
+		}
+		pluginName, ok := organizationFolderPluginNames[organizationFolder.Provider]
+		if !ok {
+			var providers []string
+			for _, provider := range virtuslabv1alpha1.AllowedOrganizationProviders {
+				providers = append(providers, string(provider))
+			}
+			allErrs = append(allErrs, field.NotSupported(organizationFolderPath.Child("provider"), organizationFolder.Provider, providers))
+		} else if !plugins.IsConfigured(jenkins.Spec.Master.Plugins, pluginName) {
+			allErrs = append(allErrs, field.Invalid(organizationFolderPath.Child("provider"), organizationFolder.Provider,
+				fmt.Sprintf("organization folder requires '%s' plugin in spec.master.plugins", pluginName)))
+		}
+	}
+
+	triggers := seedJob.Triggers
+	if seedjobs.GetSeedJobType(seedJob) != virtuslabv1alpha1.JobDSLSeedJobType &&
+		(len(triggers.PollSCM) > 0 || triggers.GitHubPush || triggers.GitLabPush || triggers.BitbucketPush) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("triggers"), "triggers can be used only with jobDsl seed job"))
+	}
+	return allErrs
+}
+
+func validateSeedJobTriggers(jenkins *virtuslabv1alpha1.Jenkins, seedJob virtuslabv1alpha1.SeedJob, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(seedJob.Triggers.PollSCM) > 0 && !isCronSpecValid(seedJob.Triggers.PollSCM) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("pollSCM"), seedJob.Triggers.PollSCM, "not valid cron expression"))
+	}
+
+	pushTriggers := []struct {
+		name       string
+		pluginName string
+		enabled    bool
+	}{
+		{name: "githubPush", pluginName: gitHubPluginName, enabled: seedJob.Triggers.GitHubPush},
+		{name: "gitlabPush", pluginName: gitLabPluginName, enabled: seedJob.Triggers.GitLabPush},
+		{name: "bitbucketPush", pluginName: bitbucketPluginName, enabled: seedJob.Triggers.BitbucketPush},
+	}
+	for _, trigger := range pushTriggers {
+		if trigger.enabled && !plugins.IsConfigured(jenkins.Spec.Master.Plugins, trigger.pluginName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(trigger.name), trigger.enabled,
+				fmt.Sprintf("push trigger requires '%s' plugin in spec.master.plugins", trigger.pluginName)))
+		}
+	}
+	return allErrs
+}
+
+// isCronSpecValid checks if every line of Jenkins cron specification has five fields or is an alias like @daily
+func isCronSpecValid(spec string) bool {
+	for _, line := range strings.Split(spec, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "@") {
+			if !cronAliases[line] {
+				return false
+			}
+			continue
+		}
+		if len(strings.Fields(line)) != 5 {
+			return false
+		}
+	}
+	return true
+}
+
+func isSeedJobTypeAllowed(seedJobType virtuslabv1alpha1.SeedJobType) bool {
+	for _, allowedType := range virtuslabv1alpha1.AllowedSeedJobTypes {
+		if allowedType == seedJobType {
+			return true
+		}
 	}
+	return false
+}
+
+func isSeedJobsRemovalPolicyAllowed(policy virtuslabv1alpha1.SeedJobsRemovalPolicy) bool {
+	for _, allowedPolicy := range virtuslabv1alpha1.AllowedSeedJobsRemovalPolicies {
+		if allowedPolicy == policy {
+			return true
+		}
+	}
+	return false
+}
+
+func isSSHRepositoryURL(repositoryURL string) bool {
+	return strings.Contains(repositoryURL, "git@") || strings.HasPrefix(repositoryURL, "ssh://")
+}
 
-	return backupProvider.IsConfigurationValidForUserPhase(r.k8sClient, *r.jenkins, r.logger)
+func isHTTPRepositoryURL(repositoryURL string) bool {
+	return strings.HasPrefix(repositoryURL, "https://") || strings.HasPrefix(repositoryURL, "http://")
 }
 
-func (r *ReconcileUserConfiguration) validateSeedJobs(jenkins *virtuslabv1alpha1.Jenkins) (bool, error) {
-	valid := true
-	if jenkins.Spec.SeedJobs != nil {
-		for _, seedJob := range jenkins.Spec.SeedJobs {
-			logger := r.logger.WithValues("seedJob", fmt.Sprintf("%+v", seedJob)).V(log.VWarn)
+func (r *ReconcileUserConfiguration) validateCredentials(jenkins *virtuslabv1alpha1.Jenkins) (field.ErrorList, error) {
+	allErrs := field.ErrorList{}
+	// seed jobs create credentials named after their IDs
+	seedJobIDs := map[string]bool{}
+	for _, seedJob := range jenkins.Spec.SeedJobs {
+		seedJobIDs[seedJob.ID] = true
+	}
+	ids := map[string]bool{}
+	for index, credential := range jenkins.Spec.Credentials {
+		credentialPath := field.NewPath("spec", "credentials").Index(index)
+
+		// credential ID is used as part of the key in managed credentials secret
+		if !credentialIDRegexp.MatchString(credential.ID) {
+			allErrs = append(allErrs, field.Invalid(credentialPath.Child("id"), credential.ID,
+				fmt.Sprintf("credential id must match '%s'", credentialIDRegexp.String())))
+		}
+		if ids[credential.ID] || seedJobIDs[credential.ID] {
+			allErrs = append(allErrs, field.Duplicate(credentialPath.Child("id"), credential.ID))
+		}
+		ids[credential.ID] = true
 
-			// validate seed job id is not empty
-			if len(seedJob.ID) == 0 {
-				logger.Info("seed job id can't be empty")
-				valid = false
+		if !isCredentialTypeAllowed(credential.Type) {
+			var credentialTypes []string
+			for _, allowedType := range virtuslabv1alpha1.AllowedCredentialTypes {
+				credentialTypes = append(credentialTypes, string(allowedType))
 			}
+			allErrs = append(allErrs, field.NotSupported(credentialPath.Child("type"), credential.Type, credentialTypes))
+			continue
+		}
 
-			// validate repository url match private key
-			if strings.Contains(seedJob.RepositoryURL, "git@") {
-				if seedJob.PrivateKey.SecretKeyRef == nil {
-					logger.Info("private key can't be empty while using ssh repository url")
-					valid = false
-				}
+		secretRefPath := credentialPath.Child("secretRef", "name")
+		secret := &v1.Secret{}
+		namespaceName := types.NamespacedName{Namespace: jenkins.Namespace, Name: credential.SecretRef.Name}
+		err := r.k8sClient.Get(context.TODO(), namespaceName, secret)
+		if err != nil && apierrors.IsNotFound(err) {
+			allErrs = append(allErrs, field.NotFound(secretRefPath, credential.SecretRef.Name))
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		secretValid := true
+		for _, key := range credentials.GetRequiredSecretKeys(credential.Type) {
+			if len(secret.Data[key]) == 0 {
+				allErrs = append(allErrs, field.Invalid(secretRefPath, credential.SecretRef.Name,
+					fmt.Sprintf("secret doesn't contain required key '%s'", key)))
+				secretValid = false
 			}
+		}
 
-			// validate private key from secret
-			if seedJob.PrivateKey.SecretKeyRef != nil {
-				deployKeySecret := &v1.Secret{}
-				namespaceName := types.NamespacedName{Namespace: jenkins.Namespace, Name: seedJob.PrivateKey.SecretKeyRef.Name}
-				err := r.k8sClient.Get(context.TODO(), namespaceName, deployKeySecret)
-				if err != nil && apierrors.IsNotFound(err) {
-					logger.Info("secret not found")
-					valid = false
-				} else if err != nil {
-					return false, err
-				}
-
-				privateKey := string(deployKeySecret.Data[seedJob.PrivateKey.SecretKeyRef.Key])
-				if privateKey == "" {
-					logger.Info("private key is empty")
-					valid = false
-				}
-
-				if err := validatePrivateKey(privateKey); err != nil {
-					logger.Info(fmt.Sprintf("private key is invalid: %s", err))
-					valid = false
-				}
+		if secretValid && credential.Type == virtuslabv1alpha1.SSHKeyCredentialType {
+			err = validatePrivateKey(string(secret.Data[credentials.PrivateKeySecretKey]), string(secret.Data[credentials.PassphraseSecretKey]))
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(secretRefPath, credential.SecretRef.Name,
+					fmt.Sprintf("private key is invalid: %s", err)))
 			}
 		}
 	}
-	return valid, nil
+
+	return allErrs, nil
 }
 
-func validatePrivateKey(privateKey string) error {
+func isCredentialTypeAllowed(credentialType virtuslabv1alpha1.CredentialType) bool {
+	for _, allowedType := range virtuslabv1alpha1.AllowedCredentialTypes {
+		if allowedType == credentialType {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ReconcileUserConfiguration) validateConfigurationAsCode(jenkins *virtuslabv1alpha1.Jenkins) (field.ErrorList, error) {
+	allErrs := field.ErrorList{}
+	configurationAsCode := jenkins.Spec.ConfigurationAsCode
+	configurationAsCodePath := field.NewPath("spec", "configurationAsCode")
+	files := map[string]string{}
+	for index, configMapRef := range configurationAsCode.Configurations {
+		namePath := configurationAsCodePath.Child("configurations").Index(index).Child("name")
+
+		if len(configMapRef.Name) == 0 {
+			allErrs = append(allErrs, field.Required(namePath, "configuration as code config map name can't be empty"))
+			continue
+		}
+
+		configMap := &v1.ConfigMap{}
+		namespaceName := types.NamespacedName{Namespace: jenkins.Namespace, Name: configMapRef.Name}
+		err := r.k8sClient.Get(context.TODO(), namespaceName, configMap)
+		if err != nil && apierrors.IsNotFound(err) {
+			allErrs = append(allErrs, field.NotFound(namePath, configMapRef.Name))
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		// all config maps are mounted in the same directory so file names must be unique
+		for key := range configMap.Data {
+			if otherConfigMap, found := files[key]; found {
+				allErrs = append(allErrs, field.Invalid(namePath, configMapRef.Name,
+					fmt.Sprintf("configuration as code file '%s' is also defined in config map '%s'", key, otherConfigMap)))
+			}
+			files[key] = configMapRef.Name
+		}
+	}
+
+	if len(configurationAsCode.Secret.Name) > 0 {
+		secretPath := configurationAsCodePath.Child("secret", "name")
+		if len(configurationAsCode.Configurations) == 0 {
+			allErrs = append(allErrs, field.Forbidden(secretPath, "configuration as code secret is set but there are no configurations"))
+		}
+
+		secret := &v1.Secret{}
+		namespaceName := types.NamespacedName{Namespace: jenkins.Namespace, Name: configurationAsCode.Secret.Name}
+		err := r.k8sClient.Get(context.TODO(), namespaceName, secret)
+		if err != nil && apierrors.IsNotFound(err) {
+			allErrs = append(allErrs, field.NotFound(secretPath, configurationAsCode.Secret.Name))
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	return allErrs, nil
+}
+
+func (r *ReconcileUserConfiguration) validateUserConfigurationSources(jenkins *virtuslabv1alpha1.Jenkins) (field.ErrorList, error) {
+	allErrs := field.ErrorList{}
+	sourceNames := map[string]bool{}
+	for index, source := range jenkins.Spec.UserConfiguration.Sources {
+		sourcePath := field.NewPath("spec", "userConfiguration", "sources").Index(index)
+
+		if (source.ConfigMapRef == nil) == (source.SecretRef == nil) {
+			allErrs = append(allErrs, field.Invalid(sourcePath, "", "exactly one of configMapRef or secretRef must be set"))
+			continue
+		}
+
+		sourceName := resources.GetUserConfigurationSourceName(source)
+		if sourceNames[sourceName] {
+			allErrs = append(allErrs, field.Duplicate(sourcePath, sourceName))
+			continue
+		}
+		sourceNames[sourceName] = true
+
+		var err error
+		var namePath *field.Path
+		var name string
+		if source.ConfigMapRef != nil {
+			namePath, name = sourcePath.Child("configMapRef", "name"), source.ConfigMapRef.Name
+			err = r.k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: name}, &v1.ConfigMap{})
+		} else {
+			namePath, name = sourcePath.Child("secretRef", "name"), source.SecretRef.Name
+			err = r.k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: name}, &v1.Secret{})
+		}
+		if err != nil && apierrors.IsNotFound(err) {
+			allErrs = append(allErrs, field.NotFound(namePath, name))
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	return allErrs, nil
+}
+
+func (r *ReconcileUserConfiguration) validateUserConfigurationSecrets(jenkins *virtuslabv1alpha1.Jenkins) (field.ErrorList, error) {
+	allErrs := field.ErrorList{}
+	secretNames := map[string]bool{}
+	for index, secretRef := range jenkins.Spec.UserConfiguration.Secrets {
+		namePath := field.NewPath("spec", "userConfiguration", "secrets").Index(index).Child("name")
+
+		if len(secretRef.Name) == 0 {
+			allErrs = append(allErrs, field.Required(namePath, "user configuration secret name can't be empty"))
+			continue
+		}
+		if secretNames[secretRef.Name] {
+			allErrs = append(allErrs, field.Duplicate(namePath, secretRef.Name))
+			continue
+		}
+		secretNames[secretRef.Name] = true
+
+		err := r.k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: secretRef.Name}, &v1.Secret{})
+		if err != nil && apierrors.IsNotFound(err) {
+			allErrs = append(allErrs, field.NotFound(namePath, secretRef.Name))
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	return allErrs, nil
+}
+
+// validatePrivateKey validates SSH private key, supported are RSA, ECDSA and Ed25519 keys
+// in OpenSSH, PKCS#1, PKCS#8 and SEC 1 formats, encrypted keys require passphrase
+func validatePrivateKey(privateKey, passphrase string) error {
 	block, _ := pem.Decode([]byte(privateKey))
 	if block == nil {
 		return errors.New("failed to decode PEM block")
 	}
 
-	priv, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	encrypted, err := isEncryptedPrivateKey(block)
+	if err != nil {
+		return err
+	}
+	if encrypted && len(passphrase) == 0 {
+		return errors.New("private key is encrypted, passphrase is required")
+	}
+
+	var key interface{}
+	switch {
+	case encrypted && block.Type == openSSHPrivateKeyType:
+		// golang.org/x/crypto/ssh can't decrypt OpenSSH keys, Jenkins verifies the passphrase when it uses the key
+		return validateOpenSSHPublicKey(block)
+	case block.Type == encryptedPKCS8PrivateKeyType:
+		key, err = decryptPKCS8PrivateKey(block.Bytes, passphrase)
+	case encrypted:
+		key, err = ssh.ParseRawPrivateKeyWithPassphrase([]byte(privateKey), []byte(passphrase))
+	default:
+		key, err = ssh.ParseRawPrivateKey([]byte(privateKey))
+	}
 	if err != nil {
 		return err
 	}
 
-	err = priv.Validate()
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return key.Validate()
+	case *dsa.PrivateKey:
+		return errors.New("DSA keys are not supported")
+	default:
+		return nil
+	}
+}
+
+// isEncryptedPrivateKey tells whether PEM block holds a private key protected by passphrase
+func isEncryptedPrivateKey(block *pem.Block) (bool, error) {
+	switch block.Type {
+	case openSSHPrivateKeyType:
+		header, err := parseOpenSSHPrivateKeyHeader(block)
+		if err != nil {
+			return false, err
+		}
+		return header.CipherName != "none", nil
+	case encryptedPKCS8PrivateKeyType:
+		return true, nil
+	default:
+		return x509.IsEncryptedPEMBlock(block), nil
+	}
+}
+
+// openSSHPrivateKeyHeader is the unencrypted part of OpenSSH private key following openSSHPrivateKeyMagic
+type openSSHPrivateKeyHeader struct {
+	CipherName   string
+	KdfName      string
+	KdfOpts      string
+	NumKeys      uint32
+	PubKey       []byte
+	PrivKeyBlock []byte
+}
+
+func parseOpenSSHPrivateKeyHeader(block *pem.Block) (*openSSHPrivateKeyHeader, error) {
+	if !bytes.HasPrefix(block.Bytes, []byte(openSSHPrivateKeyMagic)) {
+		return nil, errors.New("invalid OpenSSH private key format")
+	}
+	header := &openSSHPrivateKeyHeader{}
+	if err := ssh.Unmarshal(block.Bytes[len(openSSHPrivateKeyMagic):], header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// validateOpenSSHPublicKey verifies type of encrypted OpenSSH private key using its public key which isn't encrypted
+func validateOpenSSHPublicKey(block *pem.Block) error {
+	header, err := parseOpenSSHPrivateKeyHeader(block)
 	if err != nil {
 		return err
 	}
+	if header.NumKeys != 1 {
+		return fmt.Errorf("OpenSSH private key must contain exactly one key, found %d", header.NumKeys)
+	}
 
-	return nil
+	publicKey, err := ssh.ParsePublicKey(header.PubKey)
+	if err != nil {
+		return err
+	}
+	switch publicKey.Type() {
+	case ssh.KeyAlgoRSA, ssh.KeyAlgoECDSA256, ssh.KeyAlgoECDSA384, ssh.KeyAlgoECDSA521, ssh.KeyAlgoED25519:
+		return nil
+	case ssh.KeyAlgoDSA:
+		return errors.New("DSA keys are not supported")
+	default:
+		return fmt.Errorf("unsupported key type '%s'", publicKey.Type())
+	}
 }
@@ -7,6 +7,8 @@ const (
 	DefaultAmountOfExecutors = 3
 	// SeedJobSuffix is a suffix added for all seed jobs
 	SeedJobSuffix = "job-dsl-seed"
+	// PipelineSeedJobSuffix is a suffix added for all Multibranch Pipeline and Organization Folder seed jobs
+	PipelineSeedJobSuffix = "pipeline-seed"
 	// DefaultJenkinsMasterImage is the default Jenkins master docker image
 	DefaultJenkinsMasterImage = "jenkins/jenkins:lts"
 	// BackupAmazonS3SecretAccessKey is the Amazon user access key used to Amazon S3 backup
@@ -17,6 +19,16 @@ const (
 	BackupJobName = OperatorName + "-backup"
 	// UserConfigurationJobName is the Jenkins job name used to configure Jenkins by groovy scripts provided by user
 	UserConfigurationJobName = OperatorName + "-user-configuration"
+	// ConfigurationAsCodeJobName is the Jenkins job name used to configure Jenkins by Configuration as Code plugin
+	ConfigurationAsCodeJobName = OperatorName + "-configuration-as-code"
+	// CredentialsJobName is the Jenkins job name used to synchronize credentials defined in Jenkins CR
+	CredentialsJobName = OperatorName + "-credentials"
 	// BackupLatestFileName is the latest backup file name
 	BackupLatestFileName = "build-history-latest.tar.gz"
+	// JenkinsFinalizerName is the finalizer used to take the final backup when Jenkins CR is deleted
+	JenkinsFinalizerName = "jenkins.virtuslab.com"
+	// JenkinsAgentFinalizerName is the finalizer used to deregister Jenkins node when JenkinsAgent CR is deleted
+	JenkinsAgentFinalizerName = "jenkinsagent.virtuslab.com"
+	// JenkinsJobFinalizerName is the finalizer used to delete Jenkins job when JenkinsJob CR is deleted
+	JenkinsJobFinalizerName = "jenkinsjob.virtuslab.com"
 )
@@ -25,22 +25,25 @@ type Groovy struct {
 	logger        logr.Logger
 	jobName       string
 	scriptsPath   string
+	secretsPath   string
 }
 
-// New creates new instance of Groovy
-func New(jenkinsClient jenkinsclient.Jenkins, k8sClient k8s.Client, logger logr.Logger, jobName, scriptsPath string) *Groovy {
+// New creates new instance of Groovy, secretsPath is the directory with mounted secrets exposed to groovy scripts
+// as the 'secrets' binding variable, it can be empty when scripts don't use secrets
+func New(jenkinsClient jenkinsclient.Jenkins, k8sClient k8s.Client, logger logr.Logger, jobName, scriptsPath, secretsPath string) *Groovy {
 	return &Groovy{
 		jenkinsClient: jenkinsClient,
 		k8sClient:     k8sClient,
 		logger:        logger,
 		jobName:       jobName,
 		scriptsPath:   scriptsPath,
+		secretsPath:   secretsPath,
 	}
 }
 
 // ConfigureGroovyJob configures jenkins job for executing groovy scripts
 func (g *Groovy) ConfigureGroovyJob() error {
-	_, created, err := g.jenkinsClient.CreateOrUpdateJob(fmt.Sprintf(configurationJobXMLFmt, g.scriptsPath), g.jobName)
+	_, created, err := g.jenkinsClient.CreateOrUpdateJob(fmt.Sprintf(configurationJobXMLFmt, g.scriptsPath, g.secretsPath), g.jobName)
 	if err != nil {
 		return err
 	}
@@ -50,29 +53,54 @@ func (g *Groovy) ConfigureGroovyJob() error {
 	return nil
 }
 
-// EnsureGroovyJob executes groovy script and verifies jenkins job status according to reconciliation loop lifecycle
-func (g *Groovy) EnsureGroovyJob(secretOrConfigMapData map[string]string, jenkins *virtuslabv1alpha1.Jenkins) (bool, error) {
+// EnsureGroovyJob executes groovy script and verifies jenkins job status according to reconciliation loop lifecycle,
+// secretsData contains files from secrets path where keys are in '<secret name>/<key>' format
+func (g *Groovy) EnsureGroovyJob(secretOrConfigMapData, secretsData map[string]string, jenkins *virtuslabv1alpha1.Jenkins) (bool, error) {
+	done, _, err := g.EnsureGroovyJobAfter("", secretOrConfigMapData, secretsData, jenkins)
+	return done, err
+}
+
+// EnsureGroovyJobAfter works like EnsureGroovyJob for scripts which must be applied after other scripts,
+// previousHash is the hash returned for the scripts applied before, so the job is executed again when any of them changes,
+// the returned hash has to be passed to the job applied next
+func (g *Groovy) EnsureGroovyJobAfter(previousHash string, secretOrConfigMapData, secretsData map[string]string,
+	jenkins *virtuslabv1alpha1.Jenkins) (done bool, hash string, err error) {
 	jobsClient := jobs.New(g.jenkinsClient, g.k8sClient, g.logger)
 
-	hash := g.calculateHash(secretOrConfigMapData)
-	done, err := jobsClient.EnsureBuildJob(g.jobName, hash, map[string]string{jobHashParameterName: hash}, jenkins, true)
+	// the job verifies that mounted files are up to date using only hash of its own scripts
+	scriptsHash := g.calculateHash(secretOrConfigMapData, secretsData)
+	hash = chainHash(previousHash, scriptsHash)
+	done, err = jobsClient.EnsureBuildJob(g.jobName, hash, map[string]string{jobHashParameterName: scriptsHash}, jenkins, true)
 	if err != nil {
-		return false, err
+		return false, "", err
+	}
+	return done, hash, nil
+}
+
+// chainHash returns hash of scripts combined with hash of scripts applied before them
+func chainHash(previousHash, hash string) string {
+	if len(previousHash) == 0 {
+		return hash
 	}
-	return done, nil
+	chained := sha256.New()
+	chained.Write([]byte(previousHash))
+	chained.Write([]byte(hash))
+	return base64.StdEncoding.EncodeToString(chained.Sum(nil))
 }
 
-func (g *Groovy) calculateHash(secretOrConfigMapData map[string]string) string {
+func (g *Groovy) calculateHash(secretOrConfigMapData, secretsData map[string]string) string {
 	hash := sha256.New()
 
-	var keys []string
-	for key := range secretOrConfigMapData {
-		keys = append(keys, key)
-	}
-	sort.Strings(keys)
-	for _, key := range keys {
-		hash.Write([]byte(key))
-		hash.Write([]byte(secretOrConfigMapData[key]))
+	for _, data := range []map[string]string{secretOrConfigMapData, secretsData} {
+		var keys []string
+		for key := range data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			hash.Write([]byte(key))
+			hash.Write([]byte(data[key]))
+		}
 	}
 	return base64.StdEncoding.EncodeToString(hash.Sum(nil))
 }
@@ -97,6 +125,7 @@ const configurationJobXMLFmt = `<?xml version='1.1' encoding='UTF-8'?>
   </properties>
   <definition class="org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition" plugin="workflow-cps@2.61">
     <script>def scriptsPath = &apos;%s&apos;
+def secretsPath = &apos;%s&apos;
 def expectedHash = params.hash
 
 node(&apos;master&apos;) {
@@ -107,7 +136,7 @@ node(&apos;master&apos;) {
     stage(&apos;Synchronizing files&apos;) {
         def complete = false
         for(int i = 1; i &lt;= 10; i++) {
-            def actualHash = calculateHash((String[])scripts, scriptsPath)
+            def actualHash = calculateHash((String[])scripts, scriptsPath, secretsPath)
             println &quot;Expected hash &apos;${expectedHash}&apos;, actual hash &apos;${actualHash}&apos;&quot;
             if(expectedHash == actualHash) {
                 complete = true
@@ -120,6 +149,9 @@ node(&apos;master&apos;) {
         }
     }
     
+    // binding variable available in all loaded scripts, usage: secrets[&apos;secret-name&apos;][&apos;key&apos;]
+    secrets = readSecrets(secretsPath)
+
     for(script in scripts) {
         stage(script) {
             load &quot;${scriptsPath}/${script}&quot;
@@ -128,7 +160,7 @@ node(&apos;master&apos;) {
 }
 
 @NonCPS
-def calculateHash(String[] scripts, String scriptsPath) {
+def calculateHash(String[] scripts, String scriptsPath, String secretsPath) {
     def hash = java.security.MessageDigest.getInstance(&quot;SHA-256&quot;)
     for(script in scripts) {
         hash.update(script.getBytes())
@@ -136,7 +168,43 @@ def calculateHash(String[] scripts, String scriptsPath) {
         def fileData = java.nio.file.Files.readAllBytes(fileLocation)
         hash.update(fileData)
     }
+    for(secretFile in listSecretFiles(secretsPath)) {
+        hash.update(secretFile.getBytes())
+        hash.update(java.nio.file.Files.readAllBytes(java.nio.file.Paths.get(secretsPath, secretFile)))
+    }
     return Base64.getEncoder().encodeToString(hash.digest())
+}
+
+@NonCPS
+def listSecretFiles(String secretsPath) {
+    def secretFiles = []
+    if(!secretsPath || !new File(secretsPath).exists()) {
+        return secretFiles
+    }
+    for(secret in new File(secretsPath).listFiles()) {
+        if(!secret.isDirectory() || secret.getName().startsWith(&apos;.&apos;)) {
+            continue
+        }
+        for(key in secret.list()) {
+            if(!key.startsWith(&apos;.&apos;)) {
+                secretFiles.add(secret.getName() + &apos;/&apos; + key)
+            }
+        }
+    }
+    return secretFiles.sort()
+}
+
+@NonCPS
+def readSecrets(String secretsPath) {
+    def secrets = [:]
+    for(secretFile in listSecretFiles(secretsPath)) {
+        def (secretName, key) = secretFile.tokenize(&apos;/&apos;)
+        if(!secrets.containsKey(secretName)) {
+            secrets[secretName] = [:]
+        }
+        secrets[secretName][key] = new String(java.nio.file.Files.readAllBytes(java.nio.file.Paths.get(secretsPath, secretFile)), &apos;UTF-8&apos;)
+    }
+    return secrets
 }</script>
     <sandbox>false</sandbox>
   </definition>
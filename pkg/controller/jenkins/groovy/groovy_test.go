@@ -0,0 +1,31 @@
+package groovy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroovy_calculateHash(t *testing.T) {
+	groovyClient := &Groovy{}
+	scripts := map[string]string{"1-script.groovy": "println 'hello'"}
+	secrets := map[string]string{"github/token": "secret-token"}
+
+	hash := groovyClient.calculateHash(scripts, secrets)
+
+	assert.Equal(t, hash, groovyClient.calculateHash(scripts, secrets))
+	assert.NotEqual(t, hash, groovyClient.calculateHash(scripts, nil))
+	assert.NotEqual(t, hash, groovyClient.calculateHash(scripts, map[string]string{"github/token": "changed-token"}))
+	assert.NotContains(t, hash, "secret-token")
+}
+
+func TestChainHash(t *testing.T) {
+	groovyClient := &Groovy{}
+	hash := groovyClient.calculateHash(map[string]string{"1-script.groovy": "println 'hello'"}, nil)
+	previousHash := groovyClient.calculateHash(map[string]string{"1-first.groovy": "println 'first'"}, nil)
+
+	assert.Equal(t, hash, chainHash("", hash))
+	assert.Equal(t, chainHash(previousHash, hash), chainHash(previousHash, hash))
+	assert.NotEqual(t, hash, chainHash(previousHash, hash))
+	assert.NotEqual(t, chainHash(previousHash, hash), chainHash(hash, hash))
+}
@@ -3,14 +3,22 @@ package jenkins
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"time"
 
 	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/backup"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/conditions"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/base"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/user"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/jobs"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/plugins"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/status"
 	"github.com/VirtusLab/jenkins-operator/pkg/event"
 	"github.com/VirtusLab/jenkins-operator/pkg/log"
+	"github.com/VirtusLab/jenkins-operator/pkg/metrics"
+	"github.com/VirtusLab/jenkins-operator/pkg/watch"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -22,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -33,12 +42,25 @@ const (
 	reasonUserConfigurationSuccess event.Reason = "BaseConfigurationFailure"
 	// reasonCRValidationFailure is the event which informs user has provided invalid configuration in Jenkins CR
 	reasonCRValidationFailure event.Reason = "CRValidationFailure"
+	// reasonPendingScriptApproval is the event which informs scripts or signatures are waiting for approval in Jenkins
+	reasonPendingScriptApproval event.Reason = "PendingScriptApproval"
+	// reasonFinalBackupFailure is the event which informs the final backup couldn't be completed before Jenkins CR deletion
+	reasonFinalBackupFailure event.Reason = "FinalBackupFailure"
 )
 
+// finalBackupTimeout is the time after which Jenkins CR is deleted even if the final backup hasn't been completed
+const finalBackupTimeout = 10 * time.Minute
+
 // Add creates a new Jenkins Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
-func Add(mgr manager.Manager, local, minikube bool, events event.Recorder) error {
-	return add(mgr, newReconciler(mgr, local, minikube, events))
+// Only objects from watched namespaces are reconciled, empty list of namespaces means all namespaces.
+// Status of seed jobs is refreshed by a separate controller, so builds run by triggers don't require the full reconciliation.
+func Add(mgr manager.Manager, local, minikube bool, events event.Recorder, namespaces []string) error {
+	namespacePredicate := watch.NamespacePredicate(namespaces)
+	if err := add(mgr, newReconciler(mgr, local, minikube, events), namespacePredicate); err != nil {
+		return err
+	}
+	return addSeedJobsStatus(mgr, newSeedJobsStatusReconciler(mgr, local, minikube), namespacePredicate)
 }
 
 // newReconciler returns a new reconcile.Reconciler
@@ -53,7 +75,7 @@ func newReconciler(mgr manager.Manager, local, minikube bool, events event.Recor
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
-func add(mgr manager.Manager, r reconcile.Reconciler) error {
+func add(mgr manager.Manager, r reconcile.Reconciler, namespacePredicate predicate.Predicate) error {
 	// Create a new controller
 	c, err := controller.New("jenkins-controller", mgr, controller.Options{Reconciler: r})
 	if err != nil {
@@ -61,7 +83,7 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	}
 
 	// Watch for changes to primary resource Jenkins
-	err = c.Watch(&source.Kind{Type: &virtuslabv1alpha1.Jenkins{}}, &handler.EnqueueRequestForObject{})
+	err = c.Watch(&source.Kind{Type: &virtuslabv1alpha1.Jenkins{}}, &handler.EnqueueRequestForObject{}, namespacePredicate)
 	if err != nil {
 		return err
 	}
@@ -70,18 +92,18 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	err = c.Watch(&source.Kind{Type: &corev1.Pod{}}, &handler.EnqueueRequestForOwner{
 		IsController: true,
 		OwnerType:    &virtuslabv1alpha1.Jenkins{},
-	})
+	}, namespacePredicate)
 	if err != nil {
 		return err
 	}
 
 	jenkinsHandler := &enqueueRequestForJenkins{}
-	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, jenkinsHandler)
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, jenkinsHandler, namespacePredicate)
 	if err != nil {
 		return err
 	}
 
-	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, jenkinsHandler)
+	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, jenkinsHandler, namespacePredicate)
 	if err != nil {
 		return err
 	}
@@ -101,14 +123,11 @@ type ReconcileJenkins struct {
 
 // Reconcile it's a main reconciliation loop which maintain desired state based on Jenkins.Spec
 func (r *ReconcileJenkins) Reconcile(request reconcile.Request) (reconcile.Result, error) {
-	logger := r.buildLogger(request.Name)
+	logger := r.buildLogger(request.Name, request.Namespace)
 	logger.V(log.VDebug).Info("Reconciling Jenkins")
 
 	result, err := r.reconcile(request, logger)
-	if err != nil && apierrors.IsConflict(err) {
-		logger.V(log.VWarn).Info(err.Error())
-		return reconcile.Result{Requeue: true}, nil
-	} else if err != nil {
+	if err != nil {
 		logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed: %+v", err))
 		return reconcile.Result{Requeue: true}, nil
 	}
@@ -130,36 +149,64 @@ func (r *ReconcileJenkins) reconcile(request reconcile.Request, logger logr.Logg
 		return reconcile.Result{}, err
 	}
 
-	err = r.setDefaults(jenkins, logger)
-	if err != nil {
-		return reconcile.Result{}, err
+	if jenkins.ObjectMeta.DeletionTimestamp != nil {
+		return r.finalize(jenkins, logger)
+	}
+
+	if !hasFinalizer(jenkins) {
+		jenkins.ObjectMeta.Finalizers = append(jenkins.ObjectMeta.Finalizers, constants.JenkinsFinalizerName)
+		return reconcile.Result{}, r.client.Update(context.TODO(), jenkins)
+	}
+
+	observedGeneration := jenkins.Generation
+	previousStatus := jenkins.Status.DeepCopy()
+	result, reconcileErr := r.reconcileJenkins(jenkins, logger)
+	if err = r.updateStatus(jenkins, previousStatus, observedGeneration, reconcileErr); err != nil {
+		if reconcileErr == nil {
+			return reconcile.Result{}, err
+		}
+		// the reconcile error is returned, the status will be updated in the next loop
+		logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't update status: %s", err))
 	}
 
+	return result, reconcileErr
+}
+
+func (r *ReconcileJenkins) reconcileJenkins(jenkins *virtuslabv1alpha1.Jenkins, logger logr.Logger) (reconcile.Result, error) {
+	SetDefaults(jenkins, logger)
+
 	// Reconcile base configuration
 	baseConfiguration := base.New(r.client, r.scheme, logger, jenkins, r.local, r.minikube)
 
-	valid, err := baseConfiguration.Validate(jenkins)
+	validationErrs, err := baseConfiguration.Validate(jenkins)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
-	if !valid {
-		r.events.Emit(jenkins, event.TypeWarning, reasonCRValidationFailure, "Base CR validation failed")
-		logger.V(log.VWarn).Info("Validation of base configuration failed, please correct Jenkins CR")
+	if len(validationErrs) > 0 {
+		message := fmt.Sprintf("Base CR validation failed: %s", validationErrs.ToAggregate())
+		r.events.Emit(jenkins, event.TypeWarning, reasonCRValidationFailure, message)
+		logger.V(log.VWarn).Info(message)
+		conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsBaseConfigured, corev1.ConditionFalse, conditions.ReasonValidationFailed, message)
 		return reconcile.Result{}, nil // don't requeue
 	}
 
+	start := time.Now()
 	result, jenkinsClient, err := baseConfiguration.Reconcile()
+	metrics.ObserveReconcile(jenkins, metrics.PhaseBase, start, err)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 	if result.Requeue {
+		conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsBaseConfigured, corev1.ConditionFalse, conditions.ReasonInProgress,
+			"Jenkins master pod is being configured")
 		return result, nil
 	}
+	conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsBaseConfigured, corev1.ConditionTrue, conditions.ReasonCompleted, "")
 
 	if jenkins.Status.BaseConfigurationCompletedTime == nil {
 		now := metav1.Now()
 		jenkins.Status.BaseConfigurationCompletedTime = &now
-		err = r.client.Update(context.TODO(), jenkins)
+		err = status.Update(r.client, jenkins)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
@@ -169,28 +216,49 @@ func (r *ReconcileJenkins) reconcile(request reconcile.Request, logger logr.Logg
 	// Reconcile user configuration
 	userConfiguration := user.New(r.client, jenkinsClient, logger, jenkins)
 
-	valid, err = userConfiguration.Validate(jenkins)
+	validationErrs, err = userConfiguration.Validate(jenkins)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
-	if !valid {
-		logger.V(log.VWarn).Info("Validation of user configuration failed, please correct Jenkins CR")
-		r.events.Emit(jenkins, event.TypeWarning, reasonCRValidationFailure, "User CR validation failed")
+	if len(validationErrs) > 0 {
+		message := fmt.Sprintf("User CR validation failed: %s", validationErrs.ToAggregate())
+		r.events.Emit(jenkins, event.TypeWarning, reasonCRValidationFailure, message)
+		logger.V(log.VWarn).Info(message)
+		conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsUserConfigured, corev1.ConditionFalse, conditions.ReasonValidationFailed, message)
 		return reconcile.Result{}, nil // don't requeue
 	}
 
+	start = time.Now()
 	result, err = userConfiguration.Reconcile()
+	metrics.ObserveReconcile(jenkins, metrics.PhaseUser, start, err)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 	if result.Requeue {
+		conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsUserConfigured, corev1.ConditionFalse, conditions.ReasonInProgress,
+			"User configuration is being applied")
 		return result, nil
 	}
+	conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsUserConfigured, corev1.ConditionTrue, conditions.ReasonCompleted, "")
+
+	scriptHashes, signatures, err := userConfiguration.GetPendingScriptApprovals()
+	if err != nil {
+		logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't get pending script approvals: %s", err))
+	} else if len(scriptHashes) > 0 || len(signatures) > 0 {
+		message := fmt.Sprintf("Scripts are waiting for approval, add them to spec.scriptApproval to approve: script hashes %v, signatures %v",
+			scriptHashes, signatures)
+		// the event is emitted only when the pending scripts change, not in every reconciliation
+		if conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsScriptsApproved, corev1.ConditionFalse, conditions.ReasonPendingScriptApproval, message) {
+			r.events.Emit(jenkins, event.TypeWarning, reasonPendingScriptApproval, message)
+		}
+	} else {
+		conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsScriptsApproved, corev1.ConditionTrue, conditions.ReasonScriptsApproved, "")
+	}
 
 	if jenkins.Status.UserConfigurationCompletedTime == nil {
 		now := metav1.Now()
 		jenkins.Status.UserConfigurationCompletedTime = &now
-		err = r.client.Update(context.TODO(), jenkins)
+		err = status.Update(r.client, jenkins)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
@@ -198,29 +266,109 @@ func (r *ReconcileJenkins) reconcile(request reconcile.Request, logger logr.Logg
 		r.events.Emit(jenkins, event.TypeNormal, reasonUserConfigurationSuccess, "User configuration completed")
 	}
 
-	return reconcile.Result{}, nil
+	return result, nil
+}
+
+// updateStatus sets Degraded and Ready conditions based on the result of reconciliation and saves status
+// when it differs from the status read at the beginning of the reconciliation
+func (r *ReconcileJenkins) updateStatus(jenkins *virtuslabv1alpha1.Jenkins, previousStatus *virtuslabv1alpha1.JenkinsStatus,
+	observedGeneration int64, reconcileErr error) error {
+	if reconcileErr != nil {
+		conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsDegraded, corev1.ConditionTrue, conditions.ReasonReconcileFailed, reconcileErr.Error())
+	} else {
+		conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsDegraded, corev1.ConditionFalse, conditions.ReasonReconcileSucceeded, "")
+	}
+
+	switch {
+	case reconcileErr != nil:
+		conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsReady, corev1.ConditionFalse, conditions.ReasonReconcileFailed, reconcileErr.Error())
+	case conditions.IsTrue(jenkins.Status, virtuslabv1alpha1.JenkinsBaseConfigured) &&
+		conditions.IsTrue(jenkins.Status, virtuslabv1alpha1.JenkinsUserConfigured):
+		conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsReady, corev1.ConditionTrue, conditions.ReasonCompleted, "")
+		// the generation is observed only when both configuration phases have completed, not when validation failed
+		jenkins.Status.ObservedGeneration = observedGeneration
+	default:
+		conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsReady, corev1.ConditionFalse, conditions.ReasonInProgress,
+			"Jenkins is not configured yet")
+	}
+	jenkins.Status.Phase = conditions.Phase(jenkins.Status)
+	// the default image is applied in memory only, status makes it visible in kubectl get jenkins
+	jenkins.Status.Image = jenkins.Spec.Master.Image
+
+	if reflect.DeepEqual(*previousStatus, jenkins.Status) {
+		return nil
+	}
+	return status.Update(r.client, jenkins)
 }
 
-func (r *ReconcileJenkins) buildLogger(jenkinsName string) logr.Logger {
-	return log.Log.WithValues("cr", jenkinsName)
+// finalize takes the final backup or deletes backup data according to Jenkins.Spec.BackupDeletionPolicy
+// and removes finalizer, the finalizer is removed anyway when it takes longer than finalBackupTimeout
+func (r *ReconcileJenkins) finalize(jenkins *virtuslabv1alpha1.Jenkins, logger logr.Logger) (reconcile.Result, error) {
+	if !hasFinalizer(jenkins) {
+		return reconcile.Result{}, nil
+	}
+
+	if jenkins.Status.BaseConfigurationCompletedTime == nil {
+		logger.Info("Skipping final backup, Jenkins master pod hasn't been configured")
+	} else if time.Since(jenkins.ObjectMeta.DeletionTimestamp.Time) > finalBackupTimeout {
+		message := fmt.Sprintf("Final backup hasn't been completed within %s, Jenkins is deleted without it", finalBackupTimeout)
+		r.events.Emit(jenkins, event.TypeWarning, reasonFinalBackupFailure, message)
+		logger.V(log.VWarn).Info(message)
+	} else {
+		jenkinsClient, err := base.NewJenkinsClient(r.client, jenkins, r.local, r.minikube)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		done, err := backup.New(jenkins, r.client, logger, jenkinsClient).EnsureFinalBackup()
+		if err == jobs.ErrorUnrecoverableBuildFailed {
+			message := "Final backup failed, Jenkins is deleted without it"
+			r.events.Emit(jenkins, event.TypeWarning, reasonFinalBackupFailure, message)
+			logger.V(log.VWarn).Info(message)
+		} else if err != nil {
+			return reconcile.Result{}, err
+		} else if !done {
+			return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 10}, nil
+		}
+	}
+
+	metrics.Forget(jenkins)
+
+	var finalizers []string
+	for _, finalizer := range jenkins.ObjectMeta.Finalizers {
+		if finalizer != constants.JenkinsFinalizerName {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	jenkins.ObjectMeta.Finalizers = finalizers
+	return reconcile.Result{}, r.client.Update(context.TODO(), jenkins)
+}
+
+func hasFinalizer(jenkins *virtuslabv1alpha1.Jenkins) bool {
+	for _, finalizer := range jenkins.ObjectMeta.Finalizers {
+		if finalizer == constants.JenkinsFinalizerName {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ReconcileJenkins) buildLogger(jenkinsName, namespace string) logr.Logger {
+	return log.Log.WithValues("cr", jenkinsName, "namespace", namespace)
 }
 
-func (r *ReconcileJenkins) setDefaults(jenkins *virtuslabv1alpha1.Jenkins, logger logr.Logger) error {
-	changed := false
+// SetDefaults sets default values of Jenkins CR spec, the controller applies them in memory in every reconciliation
+// so Jenkins CR edited by the user is not overwritten by the operator, the defaulting webhook persists them
+func SetDefaults(jenkins *virtuslabv1alpha1.Jenkins, logger logr.Logger) {
 	if len(jenkins.Spec.Master.Image) == 0 {
-		logger.Info("Setting default Jenkins master image: " + constants.DefaultJenkinsMasterImage)
-		changed = true
+		logger.V(log.VDebug).Info("Setting default Jenkins master image: " + constants.DefaultJenkinsMasterImage)
 		jenkins.Spec.Master.Image = constants.DefaultJenkinsMasterImage
 	}
 	if len(jenkins.Spec.Backup) == 0 {
-		logger.Info("Setting default backup strategy: " + virtuslabv1alpha1.JenkinsBackupTypeNoBackup)
-		logger.V(log.VWarn).Info("Backup is disable !!! Please configure backup in '.spec.backup'")
-		changed = true
+		logger.V(log.VDebug).Info("Setting default backup strategy: " + virtuslabv1alpha1.JenkinsBackupTypeNoBackup)
 		jenkins.Spec.Backup = virtuslabv1alpha1.JenkinsBackupTypeNoBackup
 	}
 	if len(jenkins.Spec.Master.Plugins) == 0 {
-		logger.Info("Setting default base plugins")
-		changed = true
+		logger.V(log.VDebug).Info("Setting default base plugins")
 		jenkins.Spec.Master.Plugins = plugins.BasePlugins()
 	}
 	_, requestCPUSet := jenkins.Spec.Master.Resources.Requests[corev1.ResourceCPU]
@@ -228,8 +376,7 @@ func (r *ReconcileJenkins) setDefaults(jenkins *virtuslabv1alpha1.Jenkins, logge
 	_, limitCPUSet := jenkins.Spec.Master.Resources.Limits[corev1.ResourceCPU]
 	_, limitMemporySet := jenkins.Spec.Master.Resources.Limits[corev1.ResourceMemory]
 	if !limitCPUSet || !limitMemporySet || !requestCPUSet || !requestMemporySet {
-		logger.Info("Setting default Jenkins master pod resource requirements")
-		changed = true
+		logger.V(log.VDebug).Info("Setting default Jenkins master pod resource requirements")
 		jenkins.Spec.Master.Resources = corev1.ResourceRequirements{
 			Requests: corev1.ResourceList{
 				corev1.ResourceCPU:    resource.MustParse("1"),
@@ -241,9 +388,4 @@ func (r *ReconcileJenkins) setDefaults(jenkins *virtuslabv1alpha1.Jenkins, logge
 			},
 		}
 	}
-
-	if changed {
-		return r.client.Update(context.TODO(), jenkins)
-	}
-	return nil
 }
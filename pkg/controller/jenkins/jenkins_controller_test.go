@@ -0,0 +1,64 @@
+package jenkins
+
+import (
+	"context"
+	"testing"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/conditions"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestUpdateStatus(t *testing.T) {
+	assert.NoError(t, virtuslabv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme))
+
+	t.Run("validation failed", func(t *testing.T) {
+		// given
+		jenkins := &virtuslabv1alpha1.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default", Generation: 2},
+			Status:     virtuslabv1alpha1.JenkinsStatus{ObservedGeneration: 1},
+		}
+		k8sClient := fake.NewFakeClient(jenkins)
+		reconciler := &ReconcileJenkins{client: k8sClient, scheme: scheme.Scheme}
+		previousStatus := jenkins.Status.DeepCopy()
+		conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsBaseConfigured, corev1.ConditionFalse, conditions.ReasonValidationFailed, "invalid")
+
+		// when
+		err := reconciler.updateStatus(jenkins, previousStatus, jenkins.Generation, nil)
+
+		// then
+		assert.NoError(t, err)
+		stored := &virtuslabv1alpha1.Jenkins{}
+		assert.NoError(t, k8sClient.Get(context.TODO(), types.NamespacedName{Name: "jenkins", Namespace: "default"}, stored))
+		assert.Equal(t, int64(1), stored.Status.ObservedGeneration)
+		assert.Equal(t, virtuslabv1alpha1.JenkinsFailedPhase, stored.Status.Phase)
+	})
+	t.Run("configuration completed", func(t *testing.T) {
+		// given
+		jenkins := &virtuslabv1alpha1.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default", Generation: 2},
+			Status:     virtuslabv1alpha1.JenkinsStatus{ObservedGeneration: 1},
+		}
+		k8sClient := fake.NewFakeClient(jenkins)
+		reconciler := &ReconcileJenkins{client: k8sClient, scheme: scheme.Scheme}
+		previousStatus := jenkins.Status.DeepCopy()
+		conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsBaseConfigured, corev1.ConditionTrue, conditions.ReasonCompleted, "")
+		conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsUserConfigured, corev1.ConditionTrue, conditions.ReasonCompleted, "")
+
+		// when
+		err := reconciler.updateStatus(jenkins, previousStatus, jenkins.Generation, nil)
+
+		// then
+		assert.NoError(t, err)
+		stored := &virtuslabv1alpha1.Jenkins{}
+		assert.NoError(t, k8sClient.Get(context.TODO(), types.NamespacedName{Name: "jenkins", Namespace: "default"}, stored))
+		assert.Equal(t, int64(2), stored.Status.ObservedGeneration)
+		assert.True(t, conditions.IsTrue(stored.Status, virtuslabv1alpha1.JenkinsReady))
+	})
+}
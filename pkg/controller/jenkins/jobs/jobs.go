@@ -1,14 +1,15 @@
 package jobs
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"strings"
 
 	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
 	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/client"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/status"
 	"github.com/VirtusLab/jenkins-operator/pkg/log"
+	"github.com/VirtusLab/jenkins-operator/pkg/metrics"
 
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,8 +27,10 @@ var (
 	ErrorUnrecoverableBuildFailed = errors.New("build failed and cannot be recovered")
 	// ErrorNotFound - this is error returned when jenkins build couldn't be found
 	ErrorNotFound = errors.New("404")
-	// BuildRetires - determines max amount of retires for failed build
-	BuildRetires = 3
+	// BuildRetries - determines max amount of retries for failed build
+	BuildRetries = 3
+	// MaxErrorMessageLength limits the length of the error message saved in Jenkins CR status
+	MaxErrorMessageLength = 1024
 )
 
 // Jobs defines Jobs API tailored for operator sdk
@@ -51,8 +54,15 @@ func New(jenkinsClient client.Jenkins, k8sClient k8s.Client, logger logr.Logger)
 // entire state is saved in Jenkins.Status.Builds section
 // function return 'true' when build finished successfully or false when reconciliation loop should requeue this function
 // preserveStatus determines that build won't be removed from Jenkins.Status.Builds section
+// when a new build is run the previous builds of the job are removed from status, so reverted configuration is built again
 func (jobs *Jobs) EnsureBuildJob(jobName, hash string, parameters map[string]string, jenkins *virtuslabv1alpha1.Jenkins, preserveStatus bool) (done bool, err error) {
-	jobs.logger.V(log.VDebug).Info(fmt.Sprintf("Ensuring build, name:'%s' hash:'%s'", jobName, hash))
+	return jobs.EnsureSharedBuildJob(jobName, "", hash, parameters, jenkins, preserveStatus)
+}
+
+// EnsureSharedBuildJob works like EnsureBuildJob for a job which builds many configuration items, e.g. seed jobs,
+// key identifies the item so only its previous builds are removed from Jenkins.Status.Builds section
+func (jobs *Jobs) EnsureSharedBuildJob(jobName, key, hash string, parameters map[string]string, jenkins *virtuslabv1alpha1.Jenkins, preserveStatus bool) (done bool, err error) {
+	jobs.logger.V(log.VDebug).Info(fmt.Sprintf("Ensuring build, name:'%s' key:'%s' hash:'%s'", jobName, key, hash))
 
 	build, err := jobs.getBuildFromStatus(jobName, hash, jenkins)
 	if err != nil {
@@ -80,6 +90,7 @@ func (jobs *Jobs) EnsureBuildJob(jobName, hash string, parameters map[string]str
 	created := metav1.Now()
 	newBuild := virtuslabv1alpha1.Build{
 		JobName:    jobName,
+		Key:        key,
 		Hash:       hash,
 		CreateTime: &created,
 	}
@@ -137,12 +148,14 @@ func (jobs *Jobs) ensureRunningBuild(build virtuslabv1alpha1.Build, jenkins *vir
 
 	if build.Status == virtuslabv1alpha1.BuildSuccessStatus {
 		jobs.logger.Info(fmt.Sprintf("Build finished successfully, %+v", build))
+		metrics.ObserveBuild(jenkins, build.JobName, build.Status)
 		return true, nil
 	}
 
 	if build.Status == virtuslabv1alpha1.BuildFailureStatus || build.Status == virtuslabv1alpha1.BuildUnstableStatus ||
 		build.Status == virtuslabv1alpha1.BuildNotBuildStatus || build.Status == virtuslabv1alpha1.BuildAbortedStatus {
 		jobs.logger.V(log.VWarn).Info(fmt.Sprintf("Build failed, %+v", build))
+		metrics.ObserveBuild(jenkins, build.JobName, build.Status)
 		return false, ErrorBuildFailed
 	}
 
@@ -152,9 +165,9 @@ func (jobs *Jobs) ensureRunningBuild(build virtuslabv1alpha1.Build, jenkins *vir
 func (jobs *Jobs) ensureFailedBuild(build virtuslabv1alpha1.Build, jenkins *virtuslabv1alpha1.Jenkins, parameters map[string]string, preserveStatus bool) (bool, error) {
 	jobs.logger.V(log.VDebug).Info(fmt.Sprintf("Ensuring failed build, %+v", build))
 
-	if build.Retires < BuildRetires {
+	if build.Retries < BuildRetries {
 		jobs.logger.V(log.VDebug).Info(fmt.Sprintf("Retrying build, %+v", build))
-		build.Retires = build.Retires + 1
+		build.Retries = build.Retries + 1
 		_, err := jobs.buildJob(build, parameters, jenkins)
 		if err != nil {
 			jobs.logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't retry build, %+v", build))
@@ -225,7 +238,7 @@ func (jobs *Jobs) removeBuildFromStatus(build virtuslabv1alpha1.Build, jenkins *
 		}
 	}
 	jenkins.Status.Builds = builds
-	err := jobs.k8sClient.Update(context.TODO(), jenkins)
+	err := status.Update(jobs.k8sClient, jenkins)
 	if err != nil {
 		return err
 	}
@@ -277,9 +290,16 @@ func (jobs *Jobs) updateBuildStatus(build virtuslabv1alpha1.Build, jenkins *virt
 		jenkins.Status.Builds[buildIndex] = build
 	} else {
 		build.CreateTime = &now
-		jenkins.Status.Builds = append(jenkins.Status.Builds, build)
+		// previous builds of the job are outdated, configuration built by them has to be built again when it's restored
+		builds := []virtuslabv1alpha1.Build{}
+		for _, existingBuild := range jenkins.Status.Builds {
+			if existingBuild.JobName != build.JobName || existingBuild.Key != build.Key {
+				builds = append(builds, existingBuild)
+			}
+		}
+		jenkins.Status.Builds = append(builds, build)
 	}
-	err := jobs.k8sClient.Update(context.TODO(), jenkins)
+	err := status.Update(jobs.k8sClient, jenkins)
 	if err != nil {
 		return err
 	}
@@ -293,3 +313,24 @@ func isNotFoundError(err error) bool {
 	}
 	return false
 }
+
+// GetErrorMessage extracts the most relevant error line from Jenkins build console output
+func GetErrorMessage(consoleOutput string) string {
+	message := ""
+	for _, line := range strings.Split(consoleOutput, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if strings.Contains(line, "Exception") || strings.HasPrefix(line, "ERROR:") {
+			message = line
+			break
+		}
+		message = line
+	}
+
+	if len(message) > MaxErrorMessageLength {
+		message = message[:MaxErrorMessageLength]
+	}
+	return message
+}
@@ -84,7 +84,7 @@ func TestSuccessEnsureJob(t *testing.T) {
 		assert.Equal(t, build.JobName, jobName)
 		assert.Equal(t, build.Hash, encodedHash)
 		assert.Equal(t, build.Number, buildNumber)
-		assert.Equal(t, build.Retires, 0)
+		assert.Equal(t, build.Retries, 0)
 		assert.NotNil(t, build.CreateTime)
 		assert.NotNil(t, build.LastUpdateTime)
 
@@ -251,7 +251,7 @@ func TestEnsureJobFailedWithMaxRetries(t *testing.T) {
 	err := fakeClient.Create(ctx, jenkins)
 	assert.NoError(t, err)
 
-	BuildRetires = 1 // override max build retries
+	BuildRetries = 1 // override max build retries
 	for reconcileAttempt := 1; reconcileAttempt <= 5; reconcileAttempt++ {
 		logger.Info(fmt.Sprintf("Reconcile attempt #%d", reconcileAttempt))
 		jenkinsClient := client.NewMockJenkins(ctrl)
@@ -336,7 +336,7 @@ func TestEnsureJobFailedWithMaxRetries(t *testing.T) {
 			assert.NoError(t, errEnsureBuildJob)
 			assert.False(t, done)
 			assert.Equal(t, build.Number, int64(1))
-			assert.Equal(t, build.Retires, 0)
+			assert.Equal(t, build.Retries, 0)
 			assert.Equal(t, build.Status, virtuslabv1alpha1.BuildRunningStatus)
 		}
 
@@ -345,7 +345,7 @@ func TestEnsureJobFailedWithMaxRetries(t *testing.T) {
 			assert.EqualError(t, errEnsureBuildJob, ErrorBuildFailed.Error())
 			assert.False(t, done)
 			assert.Equal(t, build.Number, int64(1))
-			assert.Equal(t, build.Retires, 0)
+			assert.Equal(t, build.Retries, 0)
 			assert.Equal(t, build.Status, virtuslabv1alpha1.BuildFailureStatus)
 		}
 
@@ -353,9 +353,9 @@ func TestEnsureJobFailedWithMaxRetries(t *testing.T) {
 		if reconcileAttempt == 3 {
 			assert.NoError(t, errEnsureBuildJob)
 			assert.False(t, done)
-			//assert.Equal(t, build.Retires, 1)
+			//assert.Equal(t, build.Retries, 1)
 			assert.Equal(t, build.Number, int64(2))
-			assert.Equal(t, build.Retires, 1)
+			assert.Equal(t, build.Retries, 1)
 			assert.Equal(t, build.Status, virtuslabv1alpha1.BuildRunningStatus)
 		}
 
@@ -364,7 +364,7 @@ func TestEnsureJobFailedWithMaxRetries(t *testing.T) {
 			assert.EqualError(t, errEnsureBuildJob, ErrorBuildFailed.Error())
 			assert.False(t, done)
 			assert.Equal(t, build.Number, int64(2))
-			assert.Equal(t, build.Retires, 1)
+			assert.Equal(t, build.Retries, 1)
 			assert.Equal(t, build.Status, virtuslabv1alpha1.BuildFailureStatus)
 		}
 
@@ -373,12 +373,120 @@ func TestEnsureJobFailedWithMaxRetries(t *testing.T) {
 			assert.EqualError(t, errEnsureBuildJob, ErrorUnrecoverableBuildFailed.Error())
 			assert.False(t, done)
 			assert.Equal(t, build.Number, int64(2))
-			assert.Equal(t, build.Retires, 1)
+			assert.Equal(t, build.Retries, 1)
 			assert.Equal(t, build.Status, virtuslabv1alpha1.BuildFailureStatus)
 		}
 	}
 }
 
+func TestEnsureJobWithRevertedConfiguration(t *testing.T) {
+	// given
+	ctx := context.TODO()
+	logger := logf.ZapLogger(false)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobName := "Test Job"
+	jenkins := jenkinsCustomResource()
+	fakeClient := fake.NewFakeClient()
+	err := fakeClient.Create(ctx, jenkins)
+	assert.NoError(t, err)
+
+	// when configuration changes A -> B -> A every configuration is built
+	for i, hash := range []string{"A", "B", "A"} {
+		buildNumber := int64(i + 1)
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jobs := New(jenkinsClient, fakeClient, logger)
+
+		jenkinsClient.
+			EXPECT().
+			GetJob(jobName).
+			Return(&gojenkins.Job{
+				Raw: &gojenkins.JobResponse{
+					NextBuildNumber: buildNumber,
+				},
+			}, nil)
+		jenkinsClient.
+			EXPECT().
+			BuildJob(jobName, gomock.Any()).
+			Return(int64(0), nil)
+		jenkinsClient.
+			EXPECT().
+			GetBuild(jobName, buildNumber).
+			Return(&gojenkins.Build{
+				Raw: &gojenkins.BuildResponse{
+					Result: string(virtuslabv1alpha1.BuildSuccessStatus),
+				},
+			}, nil)
+
+		done, err := jobs.EnsureBuildJob(jobName, hash, nil, jenkins, true)
+		assert.NoError(t, err)
+		assert.False(t, done)
+		done, err = jobs.EnsureBuildJob(jobName, hash, nil, jenkins, true)
+		assert.NoError(t, err)
+		assert.True(t, done)
+
+		// then
+		jenkins = &virtuslabv1alpha1.Jenkins{}
+		err = fakeClient.Get(ctx, types.NamespacedName{Name: "jenkins", Namespace: "default"}, jenkins)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(jenkins.Status.Builds))
+		build := jenkins.Status.Builds[0]
+		assert.Equal(t, hash, build.Hash)
+		assert.Equal(t, buildNumber, build.Number)
+		assert.Equal(t, virtuslabv1alpha1.BuildSuccessStatus, build.Status)
+	}
+}
+
+func TestEnsureSharedBuildJob(t *testing.T) {
+	// given
+	ctx := context.TODO()
+	logger := logf.ZapLogger(false)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobName := "Test Job"
+	jenkins := jenkinsCustomResource()
+	fakeClient := fake.NewFakeClient()
+	err := fakeClient.Create(ctx, jenkins)
+	assert.NoError(t, err)
+
+	jenkinsClient := client.NewMockJenkins(ctrl)
+	jobs := New(jenkinsClient, fakeClient, logger)
+	for buildNumber := int64(1); buildNumber <= 3; buildNumber++ {
+		jenkinsClient.
+			EXPECT().
+			GetJob(jobName).
+			Return(&gojenkins.Job{
+				Raw: &gojenkins.JobResponse{
+					NextBuildNumber: buildNumber,
+				},
+			}, nil)
+	}
+	jenkinsClient.
+		EXPECT().
+		BuildJob(jobName, gomock.Any()).
+		Return(int64(0), nil).Times(3)
+
+	// when
+	_, err = jobs.EnsureSharedBuildJob(jobName, "first", "A", nil, jenkins, true)
+	assert.NoError(t, err)
+	_, err = jobs.EnsureSharedBuildJob(jobName, "second", "B", nil, jenkins, true)
+	assert.NoError(t, err)
+	_, err = jobs.EnsureSharedBuildJob(jobName, "first", "C", nil, jenkins, true)
+	assert.NoError(t, err)
+
+	// then
+	jenkins = &virtuslabv1alpha1.Jenkins{}
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "jenkins", Namespace: "default"}, jenkins)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(jenkins.Status.Builds))
+	assert.Equal(t, "second", jenkins.Status.Builds[0].Key)
+	assert.Equal(t, "B", jenkins.Status.Builds[0].Hash)
+	assert.Equal(t, "first", jenkins.Status.Builds[1].Key)
+	assert.Equal(t, "C", jenkins.Status.Builds[1].Hash)
+}
+
 func jenkinsCustomResource() *virtuslabv1alpha1.Jenkins {
 	return &virtuslabv1alpha1.Jenkins{
 		ObjectMeta: metav1.ObjectMeta{
@@ -412,3 +520,39 @@ func jenkinsCustomResource() *virtuslabv1alpha1.Jenkins {
 		},
 	}
 }
+
+func TestGetErrorMessage(t *testing.T) {
+	tests := []struct {
+		name          string
+		consoleOutput string
+		want          string
+	}{
+		{
+			name:          "empty console output",
+			consoleOutput: "",
+			want:          "",
+		},
+		{
+			name: "exception",
+			consoleOutput: `Started by user jenkins-operator
+[Pipeline] stage
+io.jenkins.plugins.casc.ConfiguratorException: Invalid configuration elements for type class jenkins.model.Jenkins : systemMessag.
+	at io.jenkins.plugins.casc.BaseConfigurator.configure(BaseConfigurator.java:292)
+Finished: FAILURE`,
+			want: "io.jenkins.plugins.casc.ConfiguratorException: Invalid configuration elements for type class jenkins.model.Jenkins : systemMessag.",
+		},
+		{
+			name: "no exception",
+			consoleOutput: `Started by user jenkins-operator
+Timeout while synchronizing files
+Finished: FAILURE
+`,
+			want: "Finished: FAILURE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetErrorMessage(tt.consoleOutput))
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package plugins
+
+// MonitoringPluginsMap contains plugins to install by operator when monitoring is enabled in Jenkins CR
+var MonitoringPluginsMap = map[string][]Plugin{
+	Must(New("prometheus:2.0.0")).String(): {
+		Must(New("cloudbees-folder:6.7")),
+		Must(New(Jackson2ADIPlugin)),
+		Must(New("metrics:4.0.2.2")),
+		Must(New("scm-api:2.3.0")),
+		Must(New("script-security:1.50")),
+		Must(New("structs:1.17")),
+		Must(New("variant:1.1")),
+		Must(New("workflow-api:2.33")),
+		Must(New("workflow-job:2.31")),
+		Must(New("workflow-step-api:2.17")),
+		Must(New("workflow-support:3.0")),
+	},
+}
@@ -2,6 +2,8 @@ package plugins
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/VirtusLab/jenkins-operator/pkg/log"
@@ -18,11 +20,24 @@ func (p Plugin) String() string {
 	return fmt.Sprintf("%s:%s", p.Name, p.Version)
 }
 
+var (
+	// namePattern matches short names of plugins published in Jenkins update center, e.g. workflow-aggregator
+	namePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+	// versionPattern matches plugin versions, e.g. 2.6, 1.0-beta-1 or 3.2.1+1a2b3c
+	versionPattern = regexp.MustCompile(`^[A-Za-z0-9_.+-]+$`)
+)
+
 // New creates plugin from string, for example "name-of-plugin:0.0.1"
 func New(nameWithVersion string) (*Plugin, error) {
 	val := strings.SplitN(nameWithVersion, ":", 2)
 	if val == nil || len(val) != 2 {
-		return nil, fmt.Errorf("invalid plugin format '%s'", nameWithVersion)
+		return nil, fmt.Errorf("invalid plugin format '%s', expected 'name:version'", nameWithVersion)
+	}
+	if !namePattern.MatchString(val[0]) {
+		return nil, fmt.Errorf("invalid plugin name '%s' in '%s'", val[0], nameWithVersion)
+	}
+	if !versionPattern.MatchString(val[1]) {
+		return nil, fmt.Errorf("invalid plugin version '%s' in '%s'", val[1], nameWithVersion)
 	}
 	return &Plugin{
 		Name:    val[0],
@@ -39,17 +54,46 @@ func Must(plugin *Plugin, err error) Plugin {
 	return *plugin
 }
 
+// IsConfigured returns true when the plugin is one of root or dependent plugins, the version is not checked
+func IsConfigured(pluginsWithVersions map[string][]string, pluginName string) bool {
+	for rootPlugin, dependentPlugins := range pluginsWithVersions {
+		for _, nameWithVersion := range append([]string{rootPlugin}, dependentPlugins...) {
+			plugin, err := New(nameWithVersion)
+			if err == nil && plugin.Name == pluginName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // VerifyDependencies checks if all plugins have compatible versions
 func VerifyDependencies(values ...map[string][]Plugin) bool {
+	valid := true
+	for _, value := range values {
+		for rootPluginNameAndVersion := range value {
+			if _, err := New(rootPluginNameAndVersion); err != nil {
+				valid = false
+			}
+		}
+	}
+
+	for _, conflict := range FindDependencyConflicts(values...) {
+		log.Log.V(log.VWarn).Info(conflict)
+		valid = false
+	}
+
+	return valid
+}
+
+// FindDependencyConflicts returns messages describing plugins which require different versions of the same plugin
+func FindDependencyConflicts(values ...map[string][]Plugin) []string {
 	// key - plugin name, value array of versions
 	allPlugins := make(map[string][]Plugin)
-	valid := true
 
 	for _, value := range values {
 		for rootPluginNameAndVersion, plugins := range value {
-			if rootPlugin, err := New(rootPluginNameAndVersion); err != nil {
-				valid = false
-			} else {
+			if rootPlugin, err := New(rootPluginNameAndVersion); err == nil {
 				allPlugins[rootPlugin.Name] = append(allPlugins[rootPlugin.Name], Plugin{
 					Name:                     rootPlugin.Name,
 					Version:                  rootPlugin.Version,
@@ -64,26 +108,23 @@ func VerifyDependencies(values ...map[string][]Plugin) bool {
 		}
 	}
 
+	var conflicts []string
 	for pluginName, versions := range allPlugins {
-		if len(versions) == 1 {
-			continue
-		}
-
-		for _, firstVersion := range versions {
-			for _, secondVersion := range versions {
+		for i, firstVersion := range versions {
+			for _, secondVersion := range versions[i+1:] {
 				if firstVersion.Version != secondVersion.Version {
-					log.Log.V(log.VWarn).Info(fmt.Sprintf("Plugin '%s' requires version '%s' but plugin '%s' requires '%s' for plugin '%s'",
+					conflicts = append(conflicts, fmt.Sprintf("Plugin '%s' requires version '%s' but plugin '%s' requires '%s' for plugin '%s'",
 						firstVersion.rootPluginNameAndVersion,
 						firstVersion.Version,
 						secondVersion.rootPluginNameAndVersion,
 						secondVersion.Version,
 						pluginName,
 					))
-					valid = false
 				}
 			}
 		}
 	}
+	sort.Strings(conflicts)
 
-	return valid
+	return conflicts
 }
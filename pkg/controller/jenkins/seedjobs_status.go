@@ -0,0 +1,86 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	jenkinsclient "github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/client"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/conditions"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/base"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/user/seedjobs"
+	"github.com/VirtusLab/jenkins-operator/pkg/log"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// seedJobsStatusRefreshInterval defines how often status of seed jobs built by triggers in Jenkins is refreshed
+const seedJobsStatusRefreshInterval = time.Second * 30
+
+// newSeedJobsStatusReconciler returns a new reconcile.Reconciler which refreshes status of seed jobs
+func newSeedJobsStatusReconciler(mgr manager.Manager, local, minikube bool) reconcile.Reconciler {
+	return &ReconcileSeedJobsStatus{
+		client:           mgr.GetClient(),
+		local:            local,
+		minikube:         minikube,
+		newJenkinsClient: base.NewJenkinsClient,
+	}
+}
+
+// addSeedJobsStatus adds a new Controller to mgr with r as the reconcile.Reconciler
+func addSeedJobsStatus(mgr manager.Manager, r reconcile.Reconciler, namespacePredicate predicate.Predicate) error {
+	c, err := controller.New("jenkins-seedjobs-status-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	return c.Watch(&source.Kind{Type: &virtuslabv1alpha1.Jenkins{}}, &handler.EnqueueRequestForObject{}, namespacePredicate)
+}
+
+var _ reconcile.Reconciler = &ReconcileSeedJobsStatus{}
+
+// ReconcileSeedJobsStatus periodically refreshes status of seed jobs of configured Jenkins instances,
+// it only reads the last builds of seed jobs and doesn't run the base and user configuration
+type ReconcileSeedJobsStatus struct {
+	client           client.Client
+	local, minikube  bool
+	newJenkinsClient func(k8sClient client.Client, jenkins *virtuslabv1alpha1.Jenkins, local, minikube bool) (jenkinsclient.Jenkins, error)
+}
+
+// Reconcile refreshes status of seed jobs and requeues itself while Jenkins has seed jobs
+func (r *ReconcileSeedJobsStatus) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := log.Log.WithValues("cr", request.Name, "namespace", request.Namespace)
+
+	jenkins := &virtuslabv1alpha1.Jenkins{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, jenkins)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// the seed jobs are created by the user configuration, Jenkins CR update triggers the refresh again
+	if jenkins.ObjectMeta.DeletionTimestamp != nil || len(jenkins.Spec.SeedJobs) == 0 ||
+		!conditions.IsTrue(jenkins.Status, virtuslabv1alpha1.JenkinsUserConfigured) {
+		return reconcile.Result{}, nil
+	}
+
+	jenkinsClient, err := r.newJenkinsClient(r.client, jenkins, r.local, r.minikube)
+	if err == nil {
+		err = seedjobs.New(jenkinsClient, r.client, logger).UpdateStatus(jenkins)
+	}
+	if err != nil {
+		logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't refresh status of seed jobs: %s", err))
+	}
+
+	return reconcile.Result{RequeueAfter: seedJobsStatusRefreshInterval}, nil
+}
@@ -0,0 +1,81 @@
+package jenkins
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	jenkinsclient "github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/client"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/conditions"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcileSeedJobsStatus(t *testing.T) {
+	assert.NoError(t, virtuslabv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme))
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "jenkins", Namespace: "default"}}
+
+	t.Run("user configuration not completed", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkins := seedJobsJenkinsCustomResource()
+		k8sClient := fake.NewFakeClient(jenkins)
+		reconciler := newTestSeedJobsStatusReconciler(k8sClient, jenkinsclient.NewMockJenkins(ctrl))
+
+		// when
+		result, err := reconciler.Reconcile(request)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+	})
+	t.Run("status of seed jobs is refreshed periodically", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkins := seedJobsJenkinsCustomResource()
+		conditions.Set(&jenkins.Status, virtuslabv1alpha1.JenkinsUserConfigured, corev1.ConditionTrue, conditions.ReasonCompleted, "")
+		k8sClient := fake.NewFakeClient(jenkins)
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetJob("jenkins-operator-job-dsl-seed").Return(nil, errors.New("unavailable"))
+		reconciler := newTestSeedJobsStatusReconciler(k8sClient, jenkinsClient)
+
+		// when
+		result, err := reconciler.Reconcile(request)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{RequeueAfter: seedJobsStatusRefreshInterval}, result)
+		updated := &virtuslabv1alpha1.Jenkins{}
+		assert.NoError(t, k8sClient.Get(context.TODO(), request.NamespacedName, updated))
+		assert.Equal(t, []virtuslabv1alpha1.SeedJobStatus{{ID: "jenkins-operator"}}, updated.Status.SeedJobs)
+	})
+}
+
+func seedJobsJenkinsCustomResource() *virtuslabv1alpha1.Jenkins {
+	return &virtuslabv1alpha1.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Spec: virtuslabv1alpha1.JenkinsSpec{
+			SeedJobs: []virtuslabv1alpha1.SeedJob{{ID: "jenkins-operator"}},
+		},
+	}
+}
+
+func newTestSeedJobsStatusReconciler(k8sClient client.Client, jenkinsClient jenkinsclient.Jenkins) *ReconcileSeedJobsStatus {
+	return &ReconcileSeedJobsStatus{
+		client: k8sClient,
+		newJenkinsClient: func(k8sClient client.Client, jenkins *virtuslabv1alpha1.Jenkins, local, minikube bool) (jenkinsclient.Jenkins, error) {
+			return jenkinsClient, nil
+		},
+	}
+}
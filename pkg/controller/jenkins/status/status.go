@@ -0,0 +1,23 @@
+// Package status implements saving status of Jenkins CR
+package status
+
+import (
+	"context"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Update saves status of Jenkins CR using status subresource, the API server returns the stored spec without
+// defaults applied by the controller in memory so only status and resource version are copied back to jenkins
+func Update(k8sClient k8s.Client, jenkins *virtuslabv1alpha1.Jenkins) error {
+	updated := jenkins.DeepCopy()
+	if err := k8sClient.Status().Update(context.TODO(), updated); err != nil {
+		return err
+	}
+
+	jenkins.Status = updated.Status
+	jenkins.ResourceVersion = updated.ResourceVersion
+	return nil
+}
@@ -0,0 +1,81 @@
+package status
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// apiServerClient behaves like the API server with status subresource enabled, status update changes only
+// the status and the stored object is written back to the updated object
+type apiServerClient struct {
+	k8s.Client
+}
+
+func (c apiServerClient) Status() k8s.StatusWriter {
+	return c
+}
+
+func (c apiServerClient) Update(ctx context.Context, obj runtime.Object) error {
+	jenkins := obj.(*virtuslabv1alpha1.Jenkins)
+	stored := &virtuslabv1alpha1.Jenkins{}
+	if err := c.Client.Get(ctx, types.NamespacedName{Name: jenkins.Name, Namespace: jenkins.Namespace}, stored); err != nil {
+		return err
+	}
+	resourceVersion, _ := strconv.Atoi(stored.ResourceVersion)
+	stored.ResourceVersion = strconv.Itoa(resourceVersion + 1)
+	stored.Status = jenkins.Status
+	if err := c.Client.Update(ctx, stored); err != nil {
+		return err
+	}
+	stored.DeepCopyInto(jenkins)
+	return nil
+}
+
+func TestUpdate(t *testing.T) {
+	err := virtuslabv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+	k8sClient := apiServerClient{Client: fake.NewFakeClient()}
+	namespacedName := types.NamespacedName{Name: "jenkins", Namespace: "default"}
+	err = k8sClient.Create(context.TODO(), &virtuslabv1alpha1.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: namespacedName.Name, Namespace: namespacedName.Namespace},
+	})
+	assert.NoError(t, err)
+
+	for reconciliation := int64(1); reconciliation <= 2; reconciliation++ {
+		jenkins := &virtuslabv1alpha1.Jenkins{}
+		err = k8sClient.Get(context.TODO(), namespacedName, jenkins)
+		assert.NoError(t, err)
+		// defaults applied in memory by the controller
+		jenkins.Spec.Backup = virtuslabv1alpha1.JenkinsBackupTypeNoBackup
+		jenkins.Spec.Master.Image = "jenkins/jenkins:lts"
+
+		jenkins.Status.ObservedGeneration = reconciliation
+		err = Update(k8sClient, jenkins)
+		assert.NoError(t, err)
+		jenkins.Status.Phase = virtuslabv1alpha1.JenkinsRunningPhase
+		err = Update(k8sClient, jenkins)
+		assert.NoError(t, err)
+
+		assert.Equal(t, virtuslabv1alpha1.JenkinsBackup(virtuslabv1alpha1.JenkinsBackupTypeNoBackup), jenkins.Spec.Backup)
+		assert.Equal(t, "jenkins/jenkins:lts", jenkins.Spec.Master.Image)
+		stored := &virtuslabv1alpha1.Jenkins{}
+		err = k8sClient.Get(context.TODO(), namespacedName, stored)
+		assert.NoError(t, err)
+		assert.Empty(t, stored.Spec.Backup)
+		assert.Empty(t, stored.Spec.Master.Image)
+		assert.Equal(t, reconciliation, stored.Status.ObservedGeneration)
+		assert.Equal(t, virtuslabv1alpha1.JenkinsRunningPhase, stored.Status.Phase)
+		assert.Equal(t, stored.ResourceVersion, jenkins.ResourceVersion)
+	}
+}
@@ -0,0 +1,354 @@
+package jenkinsagent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	jenkinsclient "github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/client"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/base"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
+	"github.com/VirtusLab/jenkins-operator/pkg/event"
+	"github.com/VirtusLab/jenkins-operator/pkg/log"
+	"github.com/VirtusLab/jenkins-operator/pkg/watch"
+
+	"github.com/bndr/gojenkins"
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// reasonAgentRegistered is the event which informs the agent has been registered in Jenkins
+	reasonAgentRegistered event.Reason = "AgentRegistered"
+	// reasonAgentFailure is the event which informs the agent couldn't be registered in Jenkins
+	reasonAgentFailure event.Reason = "AgentFailure"
+	// reasonCRValidationFailure is the event which informs user has provided invalid configuration in JenkinsAgent CR
+	reasonCRValidationFailure event.Reason = "CRValidationFailure"
+
+	// statusRefreshInterval defines how often online status of the agent is refreshed
+	statusRefreshInterval = time.Second * 30
+	// deregisterTimeout is the time after which JenkinsAgent CR is deleted even if its node couldn't be deleted from Jenkins
+	deregisterTimeout = 5 * time.Minute
+)
+
+// Add creates a new JenkinsAgent Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+// Only objects from watched namespaces are reconciled, empty list of namespaces means all namespaces.
+func Add(mgr manager.Manager, local, minikube bool, events event.Recorder, namespaces []string) error {
+	return add(mgr, newReconciler(mgr, local, minikube, events), watch.NamespacePredicate(namespaces))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, local, minikube bool, events event.Recorder) reconcile.Reconciler {
+	return &ReconcileJenkinsAgent{
+		client:           mgr.GetClient(),
+		local:            local,
+		minikube:         minikube,
+		events:           events,
+		newJenkinsClient: base.NewJenkinsClient,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler, namespacePredicate predicate.Predicate) error {
+	c, err := controller.New("jenkinsagent-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	return c.Watch(&source.Kind{Type: &virtuslabv1alpha1.JenkinsAgent{}}, &handler.EnqueueRequestForObject{}, namespacePredicate)
+}
+
+var _ reconcile.Reconciler = &ReconcileJenkinsAgent{}
+
+// ReconcileJenkinsAgent reconciles a JenkinsAgent object
+type ReconcileJenkinsAgent struct {
+	client           client.Client
+	local, minikube  bool
+	events           event.Recorder
+	newJenkinsClient func(k8sClient client.Client, jenkins *virtuslabv1alpha1.Jenkins, local, minikube bool) (jenkinsclient.Jenkins, error)
+}
+
+// Reconcile registers the agent as a node of the referenced Jenkins instance and keeps its status up to date
+func (r *ReconcileJenkinsAgent) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := log.Log.WithValues("jenkinsagent", request.Name, "namespace", request.Namespace)
+	logger.V(log.VDebug).Info("Reconciling JenkinsAgent")
+
+	result, err := r.reconcile(request, logger)
+	if err != nil {
+		logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed: %+v", err))
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 10}, nil
+	}
+	return result, nil
+}
+
+func (r *ReconcileJenkinsAgent) reconcile(request reconcile.Request, logger logr.Logger) (reconcile.Result, error) {
+	agent := &virtuslabv1alpha1.JenkinsAgent{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, agent)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if agent.ObjectMeta.DeletionTimestamp != nil {
+		return reconcile.Result{}, r.deregister(agent, logger)
+	}
+
+	if !hasFinalizer(agent) {
+		agent.ObjectMeta.Finalizers = append(agent.ObjectMeta.Finalizers, constants.JenkinsAgentFinalizerName)
+		return reconcile.Result{}, r.client.Update(context.TODO(), agent)
+	}
+
+	if !validate(agent, logger) {
+		r.events.Emit(agent, event.TypeWarning, reasonCRValidationFailure, "JenkinsAgent CR validation failed")
+		return reconcile.Result{}, nil // don't requeue
+	}
+
+	// the node registered under another name or in another Jenkins is deleted, otherwise it would be left there
+	registeredJenkinsRef, registeredNodeName := getRegisteredNode(agent)
+	if agent.Status.Registered && (registeredJenkinsRef != agent.Spec.JenkinsRef || registeredNodeName != getNodeName(agent)) {
+		if err = r.deleteNode(agent.Namespace, registeredJenkinsRef, registeredNodeName, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	jenkinsClient, err := r.getJenkinsClient(agent)
+	if err != nil {
+		logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't connect to Jenkins '%s': %s", agent.Spec.JenkinsRef, err))
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 10}, nil
+	}
+
+	node, err := r.ensureNode(jenkinsClient, agent, logger)
+	if err != nil {
+		r.events.Emitf(agent, event.TypeWarning, reasonAgentFailure, "Couldn't register agent in Jenkins: %s", err)
+		return reconcile.Result{}, err
+	}
+
+	status := agent.Status.DeepCopy()
+	status.Registered = true
+	status.Hash = calculateHash(agent.Spec)
+	status.JenkinsRef = agent.Spec.JenkinsRef
+	status.NodeName = getNodeName(agent)
+	status.Online = !node.Raw.Offline
+	status.OfflineReason = ""
+	if node.Raw.Offline {
+		status.OfflineReason = node.Raw.OfflineCauseReason
+	}
+	if agent.Status.Registered != status.Registered || agent.Status.Hash != status.Hash ||
+		agent.Status.Online != status.Online || agent.Status.OfflineReason != status.OfflineReason ||
+		agent.Status.JenkinsRef != status.JenkinsRef || agent.Status.NodeName != status.NodeName {
+		now := metav1.Now()
+		status.LastUpdateTime = &now
+		agent.Status = *status
+		if err = r.client.Status().Update(context.TODO(), agent); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{Requeue: true, RequeueAfter: statusRefreshInterval}, nil
+}
+
+// ensureNode creates Jenkins node for the agent, the node is recreated when JenkinsAgent spec has changed
+func (r *ReconcileJenkinsAgent) ensureNode(jenkinsClient jenkinsclient.Jenkins, agent *virtuslabv1alpha1.JenkinsAgent, logger logr.Logger) (*gojenkins.Node, error) {
+	nodeName := getNodeName(agent)
+	node, err := findNode(jenkinsClient, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	if node != nil && agent.Status.Hash == calculateHash(agent.Spec) {
+		return node, nil
+	}
+
+	if node != nil {
+		logger.Info(fmt.Sprintf("JenkinsAgent spec has changed, recreating '%s' node", nodeName))
+		if _, err = jenkinsClient.DeleteNode(nodeName); err != nil {
+			return nil, err
+		}
+	}
+
+	node, err = jenkinsClient.CreateNode(nodeName, getExecutors(agent), agent.Spec.Description, agent.Spec.RemoteFS,
+		strings.Join(agent.Spec.Labels, " "), getLauncherOptions(agent))
+	if err != nil {
+		return nil, err
+	}
+	if node.Raw == nil {
+		node.Raw = &gojenkins.NodeResponse{Offline: true}
+	}
+
+	logger.Info(fmt.Sprintf("Node '%s' has been registered in Jenkins '%s'", nodeName, agent.Spec.JenkinsRef))
+	r.events.Emitf(agent, event.TypeNormal, reasonAgentRegistered, "Node '%s' has been registered in Jenkins", nodeName)
+	return node, nil
+}
+
+// deregister deletes Jenkins node of the agent and removes finalizer, the node is left when Jenkins CR doesn't exist
+// anymore, is being deleted or hasn't been configured yet, the finalizer is removed anyway when it takes longer than deregisterTimeout
+func (r *ReconcileJenkinsAgent) deregister(agent *virtuslabv1alpha1.JenkinsAgent, logger logr.Logger) error {
+	if !hasFinalizer(agent) {
+		return nil
+	}
+
+	jenkinsRef, nodeName := getRegisteredNode(agent)
+	if time.Since(agent.ObjectMeta.DeletionTimestamp.Time) > deregisterTimeout {
+		message := fmt.Sprintf("Node '%s' hasn't been deleted from Jenkins '%s' within %s, JenkinsAgent is deleted anyway",
+			nodeName, jenkinsRef, deregisterTimeout)
+		r.events.Emit(agent, event.TypeWarning, reasonAgentFailure, message)
+		logger.V(log.VWarn).Info(message)
+	} else if err := r.deleteNode(agent.Namespace, jenkinsRef, nodeName, logger); err != nil {
+		return err
+	}
+
+	var finalizers []string
+	for _, finalizer := range agent.ObjectMeta.Finalizers {
+		if finalizer != constants.JenkinsAgentFinalizerName {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	agent.ObjectMeta.Finalizers = finalizers
+	return r.client.Update(context.TODO(), agent)
+}
+
+// deleteNode deletes the node from Jenkins, there is nothing to delete when Jenkins CR doesn't exist anymore,
+// is being deleted or hasn't been configured yet
+func (r *ReconcileJenkinsAgent) deleteNode(namespace, jenkinsRef, nodeName string, logger logr.Logger) error {
+	jenkins := &virtuslabv1alpha1.Jenkins{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: jenkinsRef}, jenkins)
+	if err != nil && apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if jenkins.ObjectMeta.DeletionTimestamp != nil || jenkins.Status.BaseConfigurationCompletedTime == nil {
+		return nil
+	}
+
+	jenkinsClient, err := r.newJenkinsClient(r.client, jenkins, r.local, r.minikube)
+	if err != nil {
+		return err
+	}
+	node, err := findNode(jenkinsClient, nodeName)
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		return nil
+	}
+	if _, err = jenkinsClient.DeleteNode(nodeName); err != nil {
+		return err
+	}
+	logger.Info(fmt.Sprintf("Node '%s' has been deleted from Jenkins '%s'", nodeName, jenkinsRef))
+
+	return nil
+}
+
+func (r *ReconcileJenkinsAgent) getJenkinsClient(agent *virtuslabv1alpha1.JenkinsAgent) (jenkinsclient.Jenkins, error) {
+	jenkins := &virtuslabv1alpha1.Jenkins{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: agent.Namespace, Name: agent.Spec.JenkinsRef}, jenkins)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.newJenkinsClient(r.client, jenkins, r.local, r.minikube)
+}
+
+func findNode(jenkinsClient jenkinsclient.Jenkins, name string) (*gojenkins.Node, error) {
+	nodes, err := jenkinsClient.GetAllNodes()
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		if node.Raw != nil && node.Raw.DisplayName == name {
+			return node, nil
+		}
+	}
+	return nil, nil
+}
+
+// getRegisteredNode returns Jenkins CR name and node name the agent has been registered with,
+// spec is used for agents registered before they were recorded in status
+func getRegisteredNode(agent *virtuslabv1alpha1.JenkinsAgent) (jenkinsRef, nodeName string) {
+	jenkinsRef, nodeName = agent.Status.JenkinsRef, agent.Status.NodeName
+	if len(jenkinsRef) == 0 {
+		jenkinsRef = agent.Spec.JenkinsRef
+	}
+	if len(nodeName) == 0 {
+		nodeName = getNodeName(agent)
+	}
+	return jenkinsRef, nodeName
+}
+
+func hasFinalizer(agent *virtuslabv1alpha1.JenkinsAgent) bool {
+	for _, finalizer := range agent.ObjectMeta.Finalizers {
+		if finalizer == constants.JenkinsAgentFinalizerName {
+			return true
+		}
+	}
+	return false
+}
+
+func getNodeName(agent *virtuslabv1alpha1.JenkinsAgent) string {
+	if len(agent.Spec.Name) > 0 {
+		return agent.Spec.Name
+	}
+	return agent.Name
+}
+
+func getExecutors(agent *virtuslabv1alpha1.JenkinsAgent) int {
+	if agent.Spec.Executors > 0 {
+		return agent.Spec.Executors
+	}
+	return 1
+}
+
+// getLauncherOptions returns launcher configuration in the format expected by gojenkins CreateNode
+func getLauncherOptions(agent *virtuslabv1alpha1.JenkinsAgent) map[string]string {
+	if agent.Spec.Launcher != virtuslabv1alpha1.SSHAgentLauncherType {
+		return map[string]string{"method": "JNLPLauncher"}
+	}
+
+	port := agent.Spec.SSH.Port
+	if port == 0 {
+		port = 22
+	}
+	return map[string]string{
+		"method":        "SSHLauncher",
+		"host":          agent.Spec.SSH.Host,
+		"port":          strconv.Itoa(port),
+		"credentialsId": agent.Spec.SSH.CredentialsID,
+		"javaPath":      agent.Spec.SSH.JavaPath,
+		"jvmOptions":    agent.Spec.SSH.JVMOptions,
+	}
+}
+
+func calculateHash(spec virtuslabv1alpha1.JenkinsAgentSpec) string {
+	hash := sha256.New()
+	hash.Write([]byte(spec.JenkinsRef))
+	hash.Write([]byte(spec.Name))
+	hash.Write([]byte(spec.Description))
+	hash.Write([]byte(strings.Join(spec.Labels, " ")))
+	hash.Write([]byte(strconv.Itoa(spec.Executors)))
+	hash.Write([]byte(spec.RemoteFS))
+	hash.Write([]byte(spec.Launcher))
+	hash.Write([]byte(spec.SSH.Host))
+	hash.Write([]byte(strconv.Itoa(spec.SSH.Port)))
+	hash.Write([]byte(spec.SSH.CredentialsID))
+	hash.Write([]byte(spec.SSH.JavaPath))
+	hash.Write([]byte(spec.SSH.JVMOptions))
+	return base64.URLEncoding.EncodeToString(hash.Sum(nil))
+}
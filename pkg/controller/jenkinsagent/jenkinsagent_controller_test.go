@@ -0,0 +1,312 @@
+package jenkinsagent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	jenkinsclient "github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/client"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
+	"github.com/VirtusLab/jenkins-operator/pkg/event"
+
+	"github.com/bndr/gojenkins"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+type fakeRecorder struct{}
+
+func (fakeRecorder) Emit(object runtime.Object, eventType event.Type, reason event.Reason, message string) {
+}
+
+func (fakeRecorder) Emitf(object runtime.Object, eventType event.Type, reason event.Reason, format string, args ...interface{}) {
+}
+
+func TestReconcile(t *testing.T) {
+	// given
+	ctrl := gomock.NewController(t)
+	ctx := context.TODO()
+	defer ctrl.Finish()
+
+	err := virtuslabv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+
+	jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+	fakeClient := fake.NewFakeClient()
+	jenkins := &virtuslabv1alpha1.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"}}
+	assert.NoError(t, fakeClient.Create(ctx, jenkins))
+	agent := &virtuslabv1alpha1.JenkinsAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "agent",
+			Namespace:  "default",
+			Finalizers: []string{constants.JenkinsAgentFinalizerName},
+		},
+		Spec: virtuslabv1alpha1.JenkinsAgentSpec{
+			JenkinsRef: "jenkins",
+			Labels:     []string{"linux", "docker"},
+			Executors:  2,
+			RemoteFS:   "/home/jenkins",
+		},
+	}
+	assert.NoError(t, fakeClient.Create(ctx, agent))
+
+	reconciler := &ReconcileJenkinsAgent{
+		client: fakeClient,
+		events: fakeRecorder{},
+		newJenkinsClient: func(k8sClient client.Client, jenkins *virtuslabv1alpha1.Jenkins, local, minikube bool) (jenkinsclient.Jenkins, error) {
+			return jenkinsClient, nil
+		},
+	}
+
+	jenkinsClient.EXPECT().GetAllNodes().Return([]*gojenkins.Node{}, nil)
+	jenkinsClient.EXPECT().
+		CreateNode("agent", 2, "", "/home/jenkins", "linux docker", map[string]string{"method": "JNLPLauncher"}).
+		Return(&gojenkins.Node{Raw: &gojenkins.NodeResponse{DisplayName: "agent", Offline: true, OfflineCauseReason: "not connected"}}, nil)
+
+	// when
+	result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: "agent", Namespace: "default"}})
+
+	// then
+	assert.NoError(t, err)
+	assert.True(t, result.Requeue)
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "agent", Namespace: "default"}, agent)
+	assert.NoError(t, err)
+	assert.True(t, agent.Status.Registered)
+	assert.False(t, agent.Status.Online)
+	assert.Equal(t, "not connected", agent.Status.OfflineReason)
+	assert.Equal(t, calculateHash(agent.Spec), agent.Status.Hash)
+	assert.NotNil(t, agent.Status.LastUpdateTime)
+
+	// node is not recreated when spec hasn't changed
+	jenkinsClient.EXPECT().GetAllNodes().Return([]*gojenkins.Node{
+		{Raw: &gojenkins.NodeResponse{DisplayName: "agent", Offline: false}},
+	}, nil)
+
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: "agent", Namespace: "default"}})
+
+	assert.NoError(t, err)
+	agent = &virtuslabv1alpha1.JenkinsAgent{}
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "agent", Namespace: "default"}, agent)
+	assert.NoError(t, err)
+	assert.True(t, agent.Status.Online)
+	assert.Empty(t, agent.Status.OfflineReason)
+}
+
+func TestReconcile_registeredInAnotherJenkins(t *testing.T) {
+	// given
+	ctrl := gomock.NewController(t)
+	ctx := context.TODO()
+	defer ctrl.Finish()
+
+	err := virtuslabv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+
+	previousJenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+	jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+	fakeClient := fake.NewFakeClient()
+	assert.NoError(t, fakeClient.Create(ctx, jenkinsCustomResource("previous")))
+	assert.NoError(t, fakeClient.Create(ctx, jenkinsCustomResource("jenkins")))
+	agent := jenkinsAgentCustomResource()
+	agent.Status = virtuslabv1alpha1.JenkinsAgentStatus{Registered: true, JenkinsRef: "previous", NodeName: "agent"}
+	assert.NoError(t, fakeClient.Create(ctx, agent))
+	reconciler := newTestReconciler(fakeClient, map[string]jenkinsclient.Jenkins{
+		"previous": previousJenkinsClient,
+		"jenkins":  jenkinsClient,
+	})
+
+	previousJenkinsClient.EXPECT().GetAllNodes().Return([]*gojenkins.Node{
+		{Raw: &gojenkins.NodeResponse{DisplayName: "agent"}},
+	}, nil)
+	previousJenkinsClient.EXPECT().DeleteNode("agent").Return(true, nil)
+	jenkinsClient.EXPECT().GetAllNodes().Return([]*gojenkins.Node{}, nil)
+	jenkinsClient.EXPECT().
+		CreateNode("agent", 2, "", "/home/jenkins", "linux docker", map[string]string{"method": "JNLPLauncher"}).
+		Return(&gojenkins.Node{Raw: &gojenkins.NodeResponse{DisplayName: "agent"}}, nil)
+
+	// when
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: "agent", Namespace: "default"}})
+
+	// then
+	assert.NoError(t, err)
+	agent = &virtuslabv1alpha1.JenkinsAgent{}
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "agent", Namespace: "default"}, agent)
+	assert.NoError(t, err)
+	assert.Equal(t, "jenkins", agent.Status.JenkinsRef)
+	assert.Equal(t, "agent", agent.Status.NodeName)
+}
+
+func TestReconcileJenkinsAgent_deregister(t *testing.T) {
+	err := virtuslabv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+
+	t.Run("node is deleted from Jenkins", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		fakeClient := fake.NewFakeClient()
+		assert.NoError(t, fakeClient.Create(context.TODO(), jenkinsCustomResource("jenkins")))
+		agent := jenkinsAgentCustomResource()
+		agent.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		assert.NoError(t, fakeClient.Create(context.TODO(), agent))
+		reconciler := newTestReconciler(fakeClient, map[string]jenkinsclient.Jenkins{"jenkins": jenkinsClient})
+
+		jenkinsClient.EXPECT().GetAllNodes().Return([]*gojenkins.Node{
+			{Raw: &gojenkins.NodeResponse{DisplayName: "agent"}},
+		}, nil)
+		jenkinsClient.EXPECT().DeleteNode("agent").Return(true, nil)
+
+		// when
+		err := reconciler.deregister(agent, logf.ZapLogger(false))
+
+		// then
+		assert.NoError(t, err)
+		assertFinalizerRemoved(t, fakeClient)
+	})
+	t.Run("Jenkins is being deleted", func(t *testing.T) {
+		// given
+		fakeClient := fake.NewFakeClient()
+		jenkins := jenkinsCustomResource("jenkins")
+		jenkins.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		assert.NoError(t, fakeClient.Create(context.TODO(), jenkins))
+		agent := jenkinsAgentCustomResource()
+		agent.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		assert.NoError(t, fakeClient.Create(context.TODO(), agent))
+		reconciler := newTestReconciler(fakeClient, map[string]jenkinsclient.Jenkins{})
+
+		// when
+		err := reconciler.deregister(agent, logf.ZapLogger(false))
+
+		// then
+		assert.NoError(t, err)
+		assertFinalizerRemoved(t, fakeClient)
+	})
+	t.Run("Jenkins hasn't been configured", func(t *testing.T) {
+		// given
+		fakeClient := fake.NewFakeClient()
+		jenkins := jenkinsCustomResource("jenkins")
+		jenkins.Status.BaseConfigurationCompletedTime = nil
+		assert.NoError(t, fakeClient.Create(context.TODO(), jenkins))
+		agent := jenkinsAgentCustomResource()
+		agent.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		assert.NoError(t, fakeClient.Create(context.TODO(), agent))
+		reconciler := newTestReconciler(fakeClient, map[string]jenkinsclient.Jenkins{})
+
+		// when
+		err := reconciler.deregister(agent, logf.ZapLogger(false))
+
+		// then
+		assert.NoError(t, err)
+		assertFinalizerRemoved(t, fakeClient)
+	})
+	t.Run("Jenkins is unreachable", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		fakeClient := fake.NewFakeClient()
+		assert.NoError(t, fakeClient.Create(context.TODO(), jenkinsCustomResource("jenkins")))
+		agent := jenkinsAgentCustomResource()
+		agent.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		assert.NoError(t, fakeClient.Create(context.TODO(), agent))
+		reconciler := newTestReconciler(fakeClient, map[string]jenkinsclient.Jenkins{"jenkins": jenkinsClient})
+
+		jenkinsClient.EXPECT().GetAllNodes().Return(nil, errors.New("connection refused"))
+
+		// when
+		err := reconciler.deregister(agent, logf.ZapLogger(false))
+
+		// then
+		assert.Error(t, err)
+
+		// when
+		agent.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-deregisterTimeout - time.Minute)}
+		err = reconciler.deregister(agent, logf.ZapLogger(false))
+
+		// then
+		assert.NoError(t, err)
+		assertFinalizerRemoved(t, fakeClient)
+	})
+}
+
+func TestGetLauncherOptions(t *testing.T) {
+	t.Run("JNLP launcher is default", func(t *testing.T) {
+		agent := &virtuslabv1alpha1.JenkinsAgent{}
+
+		assert.Equal(t, map[string]string{"method": "JNLPLauncher"}, getLauncherOptions(agent))
+	})
+	t.Run("SSH launcher with default port", func(t *testing.T) {
+		agent := &virtuslabv1alpha1.JenkinsAgent{
+			Spec: virtuslabv1alpha1.JenkinsAgentSpec{
+				Launcher: virtuslabv1alpha1.SSHAgentLauncherType,
+				SSH: virtuslabv1alpha1.SSHLauncher{
+					Host:          "agent.example.com",
+					CredentialsID: "agent-ssh-key",
+				},
+			},
+		}
+
+		options := getLauncherOptions(agent)
+
+		assert.Equal(t, "SSHLauncher", options["method"])
+		assert.Equal(t, "agent.example.com", options["host"])
+		assert.Equal(t, "22", options["port"])
+		assert.Equal(t, "agent-ssh-key", options["credentialsId"])
+	})
+}
+
+func jenkinsCustomResource(name string) *virtuslabv1alpha1.Jenkins {
+	now := metav1.Now()
+	return &virtuslabv1alpha1.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status:     virtuslabv1alpha1.JenkinsStatus{BaseConfigurationCompletedTime: &now},
+	}
+}
+
+func jenkinsAgentCustomResource() *virtuslabv1alpha1.JenkinsAgent {
+	return &virtuslabv1alpha1.JenkinsAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "agent",
+			Namespace:  "default",
+			Finalizers: []string{constants.JenkinsAgentFinalizerName},
+		},
+		Spec: virtuslabv1alpha1.JenkinsAgentSpec{
+			JenkinsRef: "jenkins",
+			Labels:     []string{"linux", "docker"},
+			Executors:  2,
+			RemoteFS:   "/home/jenkins",
+		},
+	}
+}
+
+func newTestReconciler(k8sClient client.Client, jenkinsClients map[string]jenkinsclient.Jenkins) *ReconcileJenkinsAgent {
+	return &ReconcileJenkinsAgent{
+		client: k8sClient,
+		events: fakeRecorder{},
+		newJenkinsClient: func(k8sClient client.Client, jenkins *virtuslabv1alpha1.Jenkins, local, minikube bool) (jenkinsclient.Jenkins, error) {
+			jenkinsClient, ok := jenkinsClients[jenkins.Name]
+			if !ok {
+				return nil, fmt.Errorf("unexpected Jenkins '%s'", jenkins.Name)
+			}
+			return jenkinsClient, nil
+		},
+	}
+}
+
+func assertFinalizerRemoved(t *testing.T, k8sClient client.Client) {
+	agent := &virtuslabv1alpha1.JenkinsAgent{}
+	err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: "agent", Namespace: "default"}, agent)
+	assert.NoError(t, err)
+	assert.Empty(t, agent.Finalizers)
+}
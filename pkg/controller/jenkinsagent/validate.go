@@ -0,0 +1,60 @@
+package jenkinsagent
+
+import (
+	"fmt"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	"github.com/VirtusLab/jenkins-operator/pkg/log"
+
+	"github.com/go-logr/logr"
+)
+
+// validate validates JenkinsAgent CR Spec section
+func validate(agent *virtuslabv1alpha1.JenkinsAgent, logger logr.Logger) bool {
+	valid := true
+	if len(agent.Spec.JenkinsRef) == 0 {
+		logger.V(log.VWarn).Info("jenkinsRef can't be empty")
+		valid = false
+	}
+
+	if len(agent.Spec.RemoteFS) == 0 {
+		logger.V(log.VWarn).Info("remoteFS can't be empty")
+		valid = false
+	}
+
+	if agent.Spec.Executors < 0 {
+		logger.V(log.VWarn).Info("executors can't be negative")
+		valid = false
+	}
+
+	if len(agent.Spec.Launcher) > 0 && !isLauncherTypeAllowed(agent.Spec.Launcher) {
+		logger.V(log.VWarn).Info(fmt.Sprintf("Invalid launcher '%s', allowed launchers '%+v'", agent.Spec.Launcher, virtuslabv1alpha1.AllowedAgentLauncherTypes))
+		valid = false
+	}
+
+	if agent.Spec.Launcher == virtuslabv1alpha1.SSHAgentLauncherType {
+		if len(agent.Spec.SSH.Host) == 0 {
+			logger.V(log.VWarn).Info("ssh.host can't be empty")
+			valid = false
+		}
+		if len(agent.Spec.SSH.CredentialsID) == 0 {
+			logger.V(log.VWarn).Info("ssh.credentialsId can't be empty")
+			valid = false
+		}
+		if agent.Spec.SSH.Port < 0 || agent.Spec.SSH.Port > 65535 {
+			logger.V(log.VWarn).Info(fmt.Sprintf("Invalid ssh.port '%d'", agent.Spec.SSH.Port))
+			valid = false
+		}
+	}
+
+	return valid
+}
+
+func isLauncherTypeAllowed(launcher virtuslabv1alpha1.AgentLauncherType) bool {
+	for _, allowed := range virtuslabv1alpha1.AllowedAgentLauncherTypes {
+		if launcher == allowed {
+			return true
+		}
+	}
+	return false
+}
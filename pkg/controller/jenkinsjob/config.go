@@ -0,0 +1,113 @@
+package jenkinsjob
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"text/template"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultRepositoryBranch = "master"
+	defaultScriptPath       = "Jenkinsfile"
+)
+
+// getConfigXML returns config.xml of the job, Pipeline job definition is rendered with script read from config map
+func getConfigXML(k8sClient client.Client, job *virtuslabv1alpha1.JenkinsJob) (string, error) {
+	if job.Spec.Pipeline == nil {
+		return job.Spec.ConfigXML, nil
+	}
+
+	script := ""
+	if job.Spec.Pipeline.ScriptConfigMapRef != nil {
+		configMap := &corev1.ConfigMap{}
+		namespaceName := types.NamespacedName{Namespace: job.Namespace, Name: job.Spec.Pipeline.ScriptConfigMapRef.Name}
+		if err := k8sClient.Get(context.TODO(), namespaceName, configMap); err != nil {
+			return "", err
+		}
+		var ok bool
+		script, ok = configMap.Data[job.Spec.Pipeline.ScriptConfigMapRef.Key]
+		if !ok {
+			return "", fmt.Errorf("key '%s' not found in config map '%s'", job.Spec.Pipeline.ScriptConfigMapRef.Key, configMap.Name)
+		}
+	}
+
+	return pipelineConfigXML(*job.Spec.Pipeline, script)
+}
+
+// pipelineConfigXML returns XML representation of Pipeline job with inline script or script from Git repository
+func pipelineConfigXML(pipeline virtuslabv1alpha1.JenkinsJobPipeline, script string) (string, error) {
+	data := struct {
+		Pipeline virtuslabv1alpha1.JenkinsJobPipeline
+		Script   string
+	}{
+		Pipeline: pipeline,
+		Script:   script,
+	}
+	if len(data.Pipeline.RepositoryBranch) == 0 {
+		data.Pipeline.RepositoryBranch = defaultRepositoryBranch
+	}
+	if len(data.Pipeline.ScriptPath) == 0 {
+		data.Pipeline.ScriptPath = defaultScriptPath
+	}
+
+	var buffer bytes.Buffer
+	if err := pipelineConfigXMLTemplate.Execute(&buffer, data); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+func escapeXML(value string) (string, error) {
+	var buffer bytes.Buffer
+	if err := xml.EscapeText(&buffer, []byte(value)); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+var pipelineConfigXMLTemplate = template.Must(template.New("pipeline").Funcs(template.FuncMap{"xml": escapeXML}).Parse(`<?xml version='1.0' encoding='UTF-8'?>
+<flow-definition plugin="workflow-job">
+  <actions/>
+  <description>{{ xml .Pipeline.Description }}</description>
+  <keepDependencies>false</keepDependencies>
+  <properties/>
+{{- if .Pipeline.ScriptConfigMapRef }}
+  <definition class="org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition" plugin="workflow-cps">
+    <script>{{ xml .Script }}</script>
+    <sandbox>{{ .Pipeline.Sandbox }}</sandbox>
+  </definition>
+{{- else }}
+  <definition class="org.jenkinsci.plugins.workflow.cps.CpsScmFlowDefinition" plugin="workflow-cps">
+    <scm class="hudson.plugins.git.GitSCM" plugin="git">
+      <configVersion>2</configVersion>
+      <userRemoteConfigs>
+        <hudson.plugins.git.UserRemoteConfig>
+          <url>{{ xml .Pipeline.RepositoryURL }}</url>
+          <credentialsId>{{ xml .Pipeline.CredentialsID }}</credentialsId>
+        </hudson.plugins.git.UserRemoteConfig>
+      </userRemoteConfigs>
+      <branches>
+        <hudson.plugins.git.BranchSpec>
+          <name>*/{{ xml .Pipeline.RepositoryBranch }}</name>
+        </hudson.plugins.git.BranchSpec>
+      </branches>
+      <doGenerateSubmoduleConfigurations>false</doGenerateSubmoduleConfigurations>
+      <submoduleCfg class="list"/>
+      <extensions/>
+    </scm>
+    <scriptPath>{{ xml .Pipeline.ScriptPath }}</scriptPath>
+    <lightweight>true</lightweight>
+  </definition>
+{{- end }}
+  <triggers/>
+  <disabled>false</disabled>
+</flow-definition>
+`))
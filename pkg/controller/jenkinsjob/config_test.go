@@ -0,0 +1,75 @@
+package jenkinsjob
+
+import (
+	"encoding/xml"
+	"testing"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPipelineConfigXML(t *testing.T) {
+	t.Run("inline script", func(t *testing.T) {
+		pipeline := virtuslabv1alpha1.JenkinsJobPipeline{
+			Description:        "<build>",
+			ScriptConfigMapRef: &corev1.ConfigMapKeySelector{Key: "Jenkinsfile"},
+			Sandbox:            true,
+		}
+
+		config, err := pipelineConfigXML(pipeline, "node { sh 'echo \"a && b\"' }")
+
+		assert.NoError(t, err)
+		var parsed struct {
+			Description string `xml:"description"`
+			Definition  struct {
+				Class   string `xml:"class,attr"`
+				Script  string `xml:"script"`
+				Sandbox bool   `xml:"sandbox"`
+			} `xml:"definition"`
+		}
+		assert.NoError(t, xml.Unmarshal([]byte(config), &parsed))
+		assert.Equal(t, "<build>", parsed.Description)
+		assert.Equal(t, "org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition", parsed.Definition.Class)
+		assert.Equal(t, "node { sh 'echo \"a && b\"' }", parsed.Definition.Script)
+		assert.True(t, parsed.Definition.Sandbox)
+	})
+	t.Run("script from repository with defaults", func(t *testing.T) {
+		pipeline := virtuslabv1alpha1.JenkinsJobPipeline{
+			RepositoryURL: "https://github.com/VirtusLab/jenkins-operator.git",
+			CredentialsID: "github",
+		}
+
+		config, err := pipelineConfigXML(pipeline, "")
+
+		assert.NoError(t, err)
+		var parsed struct {
+			Definition struct {
+				Class string `xml:"class,attr"`
+				SCM   struct {
+					URL           string `xml:"userRemoteConfigs>hudson.plugins.git.UserRemoteConfig>url"`
+					CredentialsID string `xml:"userRemoteConfigs>hudson.plugins.git.UserRemoteConfig>credentialsId"`
+					Branch        string `xml:"branches>hudson.plugins.git.BranchSpec>name"`
+				} `xml:"scm"`
+				ScriptPath string `xml:"scriptPath"`
+			} `xml:"definition"`
+		}
+		assert.NoError(t, xml.Unmarshal([]byte(config), &parsed))
+		assert.Equal(t, "org.jenkinsci.plugins.workflow.cps.CpsScmFlowDefinition", parsed.Definition.Class)
+		assert.Equal(t, pipeline.RepositoryURL, parsed.Definition.SCM.URL)
+		assert.Equal(t, "github", parsed.Definition.SCM.CredentialsID)
+		assert.Equal(t, "*/master", parsed.Definition.SCM.Branch)
+		assert.Equal(t, "Jenkinsfile", parsed.Definition.ScriptPath)
+	})
+}
+
+func TestGetJobPath(t *testing.T) {
+	job := &virtuslabv1alpha1.JenkinsJob{}
+	job.Name = "build"
+	assert.Equal(t, "build", getJobPath(job))
+
+	job.Spec.Folders = []string{"team", "backend"}
+	assert.Equal(t, "team/job/backend/job/build", getJobPath(job))
+	assert.Equal(t, []string{"team", "backend"}, job.Spec.Folders)
+}
@@ -0,0 +1,377 @@
+package jenkinsjob
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	jenkinsclient "github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/client"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/base"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
+	"github.com/VirtusLab/jenkins-operator/pkg/event"
+	"github.com/VirtusLab/jenkins-operator/pkg/log"
+	"github.com/VirtusLab/jenkins-operator/pkg/watch"
+
+	"github.com/bndr/gojenkins"
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// reasonJobCreated is the event which informs the job has been created in Jenkins
+	reasonJobCreated event.Reason = "JobCreated"
+	// reasonJobFailure is the event which informs the job couldn't be created or updated in Jenkins
+	reasonJobFailure event.Reason = "JobFailure"
+	// reasonCRValidationFailure is the event which informs user has provided invalid configuration in JenkinsJob CR
+	reasonCRValidationFailure event.Reason = "CRValidationFailure"
+
+	// statusRefreshInterval defines how often the last build of the job is checked
+	statusRefreshInterval = time.Second * 30
+)
+
+// Add creates a new JenkinsJob Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+// Only objects from watched namespaces are reconciled, empty list of namespaces means all namespaces.
+func Add(mgr manager.Manager, local, minikube bool, events event.Recorder, namespaces []string) error {
+	return add(mgr, newReconciler(mgr, local, minikube, events), watch.NamespacePredicate(namespaces))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, local, minikube bool, events event.Recorder) reconcile.Reconciler {
+	return &ReconcileJenkinsJob{
+		client:           mgr.GetClient(),
+		scheme:           mgr.GetScheme(),
+		local:            local,
+		minikube:         minikube,
+		events:           events,
+		newJenkinsClient: base.NewJenkinsClient,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler, namespacePredicate predicate.Predicate) error {
+	c, err := controller.New("jenkinsjob-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	return c.Watch(&source.Kind{Type: &virtuslabv1alpha1.JenkinsJob{}}, &handler.EnqueueRequestForObject{}, namespacePredicate)
+}
+
+var _ reconcile.Reconciler = &ReconcileJenkinsJob{}
+
+// ReconcileJenkinsJob reconciles a JenkinsJob object
+type ReconcileJenkinsJob struct {
+	client           client.Client
+	scheme           *runtime.Scheme
+	local, minikube  bool
+	events           event.Recorder
+	newJenkinsClient func(k8sClient client.Client, jenkins *virtuslabv1alpha1.Jenkins, local, minikube bool) (jenkinsclient.Jenkins, error)
+}
+
+// Reconcile creates or updates the job in the referenced Jenkins instance and reports its last build in status
+func (r *ReconcileJenkinsJob) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := log.Log.WithValues("jenkinsjob", request.Name, "namespace", request.Namespace)
+	logger.V(log.VDebug).Info("Reconciling JenkinsJob")
+
+	result, err := r.reconcile(request, logger)
+	if err != nil {
+		logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed: %+v", err))
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 10}, nil
+	}
+	return result, nil
+}
+
+func (r *ReconcileJenkinsJob) reconcile(request reconcile.Request, logger logr.Logger) (reconcile.Result, error) {
+	job := &virtuslabv1alpha1.JenkinsJob{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, job)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if job.ObjectMeta.DeletionTimestamp != nil {
+		return reconcile.Result{}, r.deleteJob(job, logger)
+	}
+
+	if !validate(job, logger) {
+		r.events.Emit(job, event.TypeWarning, reasonCRValidationFailure, "JenkinsJob CR validation failed")
+		return reconcile.Result{}, nil // don't requeue
+	}
+
+	jenkins := &virtuslabv1alpha1.Jenkins{}
+	err = r.client.Get(context.TODO(), types.NamespacedName{Namespace: job.Namespace, Name: job.Spec.JenkinsRef}, jenkins)
+	if err != nil && apierrors.IsNotFound(err) {
+		logger.V(log.VWarn).Info(fmt.Sprintf("Jenkins '%s' not found", job.Spec.JenkinsRef))
+		return reconcile.Result{Requeue: true, RequeueAfter: statusRefreshInterval}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// the job is deleted together with Jenkins CR, finalizer removes it from Jenkins first
+	if !isControlledBy(job, jenkins) || !hasFinalizer(job) {
+		if !isControlledBy(job, jenkins) {
+			if err = setControllerReference(jenkins, job, r.scheme); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		if !hasFinalizer(job) {
+			job.ObjectMeta.Finalizers = append(job.ObjectMeta.Finalizers, constants.JenkinsJobFinalizerName)
+		}
+		return reconcile.Result{}, r.client.Update(context.TODO(), job)
+	}
+
+	// the job has been renamed, moved to other folders or to another Jenkins, the previous job is deleted
+	createdJenkinsRef, createdPath := getCreatedJob(job)
+	if job.Status.Created && (createdJenkinsRef != job.Spec.JenkinsRef || createdPath != getJobPath(job)) {
+		if err = r.deleteJenkinsJob(job.Namespace, createdJenkinsRef, createdPath, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	jenkinsClient, err := r.newJenkinsClient(r.client, jenkins, r.local, r.minikube)
+	if err != nil {
+		logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't connect to Jenkins '%s': %s", job.Spec.JenkinsRef, err))
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 10}, nil
+	}
+
+	config, err := getConfigXML(r.client, job)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	status := job.Status.DeepCopy()
+	status.Hash = calculateHash(job.Spec, config)
+	jenkinsJob, err := r.ensureJob(jenkinsClient, job, config, status.Hash != job.Status.Hash, logger)
+	if err != nil {
+		r.events.Emitf(job, event.TypeWarning, reasonJobFailure, "Couldn't create or update job in Jenkins: %s", err)
+		return reconcile.Result{}, err
+	}
+	status.Created = true
+	status.Path = getJobPath(job)
+	status.JenkinsRef = job.Spec.JenkinsRef
+
+	if err = updateLastBuild(jenkinsJob, status); err != nil {
+		logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't get last build of '%s' job: %s", getJobPath(job), err))
+	}
+
+	if status.Created != job.Status.Created || status.Path != job.Status.Path || status.Hash != job.Status.Hash ||
+		status.JenkinsRef != job.Status.JenkinsRef || status.LastBuildNumber != job.Status.LastBuildNumber {
+		job.Status = *status
+		if err = r.client.Status().Update(context.TODO(), job); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{Requeue: true, RequeueAfter: statusRefreshInterval}, nil
+}
+
+// ensureJob creates missing folders and the job, configuration of the existing job is updated only when it has changed,
+// the job deleted from Jenkins is created again
+func (r *ReconcileJenkinsJob) ensureJob(jenkinsClient jenkinsclient.Jenkins, job *virtuslabv1alpha1.JenkinsJob, config string,
+	changed bool, logger logr.Logger) (*gojenkins.Job, error) {
+	name := getJobName(job)
+	folders := job.Spec.Folders
+
+	if len(folders) == 0 {
+		if !changed {
+			jenkinsJob, err := jenkinsClient.GetJob(name)
+			if !jenkinsclient.IsNotFoundError(err) {
+				return jenkinsJob, err
+			}
+		}
+		jenkinsJob, created, err := jenkinsClient.CreateOrUpdateJob(config, name)
+		if err != nil {
+			return nil, err
+		}
+		r.logJobChange(job, created, logger)
+		return jenkinsJob, nil
+	}
+
+	for i, folder := range folders {
+		_, err := jenkinsClient.GetFolder(folder, folders[:i]...)
+		if jenkinsclient.IsNotFoundError(err) {
+			if _, err = jenkinsClient.CreateFolder(folder, folders[:i]...); err != nil {
+				return nil, err
+			}
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	jenkinsJob, err := jenkinsClient.GetJob(name, folders...)
+	if jenkinsclient.IsNotFoundError(err) {
+		jenkinsJob, err = jenkinsClient.CreateJobInFolder(config, name, folders...)
+		if err != nil {
+			return nil, err
+		}
+		r.logJobChange(job, true, logger)
+		return jenkinsJob, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if changed {
+		if err = jenkinsJob.UpdateConfig(config); err != nil {
+			return nil, err
+		}
+		r.logJobChange(job, false, logger)
+	}
+	return jenkinsJob, nil
+}
+
+func (r *ReconcileJenkinsJob) logJobChange(job *virtuslabv1alpha1.JenkinsJob, created bool, logger logr.Logger) {
+	if created {
+		logger.Info(fmt.Sprintf("Job '%s' has been created in Jenkins '%s'", getJobPath(job), job.Spec.JenkinsRef))
+		r.events.Emitf(job, event.TypeNormal, reasonJobCreated, "Job '%s' has been created in Jenkins", getJobPath(job))
+		return
+	}
+	logger.Info(fmt.Sprintf("Job '%s' has been updated in Jenkins '%s'", getJobPath(job), job.Spec.JenkinsRef))
+}
+
+// deleteJob deletes the job from Jenkins and removes finalizer, the job is left when Jenkins CR doesn't exist anymore
+func (r *ReconcileJenkinsJob) deleteJob(job *virtuslabv1alpha1.JenkinsJob, logger logr.Logger) error {
+	if !hasFinalizer(job) {
+		return nil
+	}
+
+	if job.Status.Created {
+		jenkinsRef, path := getCreatedJob(job)
+		if err := r.deleteJenkinsJob(job.Namespace, jenkinsRef, path, logger); err != nil {
+			return err
+		}
+	}
+
+	var finalizers []string
+	for _, finalizer := range job.ObjectMeta.Finalizers {
+		if finalizer != constants.JenkinsJobFinalizerName {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	job.ObjectMeta.Finalizers = finalizers
+	return r.client.Update(context.TODO(), job)
+}
+
+// deleteJenkinsJob deletes the job from Jenkins, there is nothing to delete when Jenkins CR doesn't exist anymore,
+// is being deleted or hasn't been configured yet
+func (r *ReconcileJenkinsJob) deleteJenkinsJob(namespace, jenkinsRef, path string, logger logr.Logger) error {
+	jenkins := &virtuslabv1alpha1.Jenkins{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: jenkinsRef}, jenkins)
+	if err != nil && apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if jenkins.ObjectMeta.DeletionTimestamp != nil || jenkins.Status.BaseConfigurationCompletedTime == nil {
+		return nil
+	}
+
+	jenkinsClient, err := r.newJenkinsClient(r.client, jenkins, r.local, r.minikube)
+	if err != nil {
+		return err
+	}
+	if _, err = jenkinsClient.DeleteJob(path); err != nil && !jenkinsclient.IsNotFoundError(err) {
+		return err
+	}
+	logger.Info(fmt.Sprintf("Job '%s' has been deleted from Jenkins '%s'", path, jenkinsRef))
+	return nil
+}
+
+// updateLastBuild sets the last completed build of the job in status, the build is fetched only when it has changed
+func updateLastBuild(jenkinsJob *gojenkins.Job, status *virtuslabv1alpha1.JenkinsJobStatus) error {
+	if jenkinsJob == nil || jenkinsJob.Raw == nil {
+		return nil
+	}
+	number := jenkinsJob.Raw.LastCompletedBuild.Number
+	if number == 0 || number == status.LastBuildNumber {
+		return nil
+	}
+
+	build, err := jenkinsJob.GetLastCompletedBuild()
+	if err != nil {
+		return err
+	}
+	status.LastBuildNumber = build.GetBuildNumber()
+	status.LastBuildResult = virtuslabv1alpha1.BuildStatus(strings.ToLower(build.GetResult()))
+	status.LastBuildTime = &metav1.Time{Time: build.GetTimestamp().Truncate(time.Second)}
+	return nil
+}
+
+// getCreatedJob returns Jenkins CR name and path the job has been created with,
+// spec is used for jobs created before they were recorded in status
+func getCreatedJob(job *virtuslabv1alpha1.JenkinsJob) (jenkinsRef, path string) {
+	jenkinsRef, path = job.Status.JenkinsRef, job.Status.Path
+	if len(jenkinsRef) == 0 {
+		jenkinsRef = job.Spec.JenkinsRef
+	}
+	if len(path) == 0 {
+		path = getJobPath(job)
+	}
+	return jenkinsRef, path
+}
+
+// isControlledBy checks if the controller of the job is the referenced Jenkins CR
+func isControlledBy(job *virtuslabv1alpha1.JenkinsJob, jenkins *virtuslabv1alpha1.Jenkins) bool {
+	controllerRef := metav1.GetControllerOf(job)
+	return controllerRef != nil && controllerRef.Name == jenkins.Name && controllerRef.UID == jenkins.UID
+}
+
+// setControllerReference sets Jenkins CR as the controller of the job,
+// the reference to the previous Jenkins CR is replaced when jenkinsRef changes
+func setControllerReference(jenkins *virtuslabv1alpha1.Jenkins, job *virtuslabv1alpha1.JenkinsJob, scheme *runtime.Scheme) error {
+	var ownerReferences []metav1.OwnerReference
+	for _, ownerReference := range job.ObjectMeta.OwnerReferences {
+		if ownerReference.Controller == nil || !*ownerReference.Controller {
+			ownerReferences = append(ownerReferences, ownerReference)
+		}
+	}
+	job.ObjectMeta.OwnerReferences = ownerReferences
+	return controllerutil.SetControllerReference(jenkins, job, scheme)
+}
+
+func hasFinalizer(job *virtuslabv1alpha1.JenkinsJob) bool {
+	for _, finalizer := range job.ObjectMeta.Finalizers {
+		if finalizer == constants.JenkinsJobFinalizerName {
+			return true
+		}
+	}
+	return false
+}
+
+func getJobName(job *virtuslabv1alpha1.JenkinsJob) string {
+	if len(job.Spec.Name) > 0 {
+		return job.Spec.Name
+	}
+	return job.Name
+}
+
+// getJobPath returns path of the job used in Jenkins URL, for example folder/job/name
+func getJobPath(job *virtuslabv1alpha1.JenkinsJob) string {
+	return strings.Join(append(append([]string{}, job.Spec.Folders...), getJobName(job)), "/job/")
+}
+
+func calculateHash(spec virtuslabv1alpha1.JenkinsJobSpec, config string) string {
+	hash := sha256.New()
+	hash.Write([]byte(spec.JenkinsRef))
+	hash.Write([]byte(strings.Join(spec.Folders, "/")))
+	hash.Write([]byte(spec.Name))
+	hash.Write([]byte(config))
+	return base64.URLEncoding.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,202 @@
+package jenkinsjob
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	jenkinsclient "github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/client"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
+	"github.com/VirtusLab/jenkins-operator/pkg/event"
+
+	"github.com/bndr/gojenkins"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const configXML = "<flow-definition/>"
+
+type fakeRecorder struct{}
+
+func (fakeRecorder) Emit(object runtime.Object, eventType event.Type, reason event.Reason, message string) {
+}
+
+func (fakeRecorder) Emitf(object runtime.Object, eventType event.Type, reason event.Reason, format string, args ...interface{}) {
+}
+
+func TestReconcile(t *testing.T) {
+	err := virtuslabv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+
+	t.Run("job deleted from Jenkins is created again", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		job := jenkinsJobCustomResource("build")
+		job.Status = virtuslabv1alpha1.JenkinsJobStatus{
+			Created: true,
+			Path:    "build",
+			Hash:    calculateHash(job.Spec, configXML),
+		}
+		fakeClient := newFakeClient(t, job)
+		reconciler := newTestReconciler(fakeClient, jenkinsClient)
+
+		jenkinsClient.EXPECT().GetJob("build").Return(nil, errors.New("404"))
+		jenkinsClient.EXPECT().CreateOrUpdateJob(configXML, "build").
+			Return(&gojenkins.Job{Raw: &gojenkins.JobResponse{}}, true, nil)
+
+		// when
+		result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: "build", Namespace: "default"}})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, statusRefreshInterval, result.RequeueAfter)
+	})
+	t.Run("renamed job is deleted from the previous path", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		job := jenkinsJobCustomResource("build")
+		job.Status = virtuslabv1alpha1.JenkinsJobStatus{
+			Created: true,
+			Path:    "build",
+			Hash:    calculateHash(job.Spec, configXML),
+		}
+		job.Spec.Name = "deploy"
+		job.Spec.Folders = []string{"team"}
+		fakeClient := newFakeClient(t, job)
+		reconciler := newTestReconciler(fakeClient, jenkinsClient)
+
+		gomock.InOrder(
+			jenkinsClient.EXPECT().DeleteJob("build").Return(true, nil),
+			jenkinsClient.EXPECT().GetFolder("team").Return(&gojenkins.Folder{}, nil),
+			jenkinsClient.EXPECT().GetJob("deploy", "team").Return(nil, errors.New("404")),
+			jenkinsClient.EXPECT().CreateJobInFolder(configXML, "deploy", "team").
+				Return(&gojenkins.Job{Raw: &gojenkins.JobResponse{}}, nil),
+		)
+
+		// when
+		_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: "build", Namespace: "default"}})
+
+		// then
+		assert.NoError(t, err)
+		job = &virtuslabv1alpha1.JenkinsJob{}
+		err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: "build", Namespace: "default"}, job)
+		assert.NoError(t, err)
+		assert.True(t, job.Status.Created)
+		assert.Equal(t, "team/job/deploy", job.Status.Path)
+		assert.Equal(t, calculateHash(job.Spec, configXML), job.Status.Hash)
+	})
+	t.Run("job moved to another Jenkins is deleted from the previous Jenkins", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		previousJenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		job := jenkinsJobCustomResource("build")
+		job.Status = virtuslabv1alpha1.JenkinsJobStatus{
+			Created:    true,
+			Path:       "build",
+			Hash:       calculateHash(job.Spec, configXML),
+			JenkinsRef: "jenkins",
+		}
+		job.Spec.JenkinsRef = "jenkins-2"
+		fakeClient := newFakeClient(t, job)
+		assert.NoError(t, fakeClient.Create(context.TODO(), jenkinsCustomResource("jenkins-2")))
+		reconciler := newTestReconciler(fakeClient, nil)
+		reconciler.newJenkinsClient = func(k8sClient client.Client, jenkins *virtuslabv1alpha1.Jenkins, local, minikube bool) (jenkinsclient.Jenkins, error) {
+			if jenkins.Name == "jenkins" {
+				return previousJenkinsClient, nil
+			}
+			return jenkinsClient, nil
+		}
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "build", Namespace: "default"}}
+
+		// when
+		_, err := reconciler.Reconcile(request)
+
+		// then
+		assert.NoError(t, err)
+		job = &virtuslabv1alpha1.JenkinsJob{}
+		err = fakeClient.Get(context.TODO(), request.NamespacedName, job)
+		assert.NoError(t, err)
+		controllerRef := metav1.GetControllerOf(job)
+		if assert.NotNil(t, controllerRef) {
+			assert.Equal(t, "jenkins-2", controllerRef.Name)
+		}
+		assert.Len(t, job.OwnerReferences, 1)
+
+		// given
+		gomock.InOrder(
+			previousJenkinsClient.EXPECT().DeleteJob("build").Return(true, nil),
+			jenkinsClient.EXPECT().CreateOrUpdateJob(configXML, "build").
+				Return(&gojenkins.Job{Raw: &gojenkins.JobResponse{}}, true, nil),
+		)
+
+		// when
+		_, err = reconciler.Reconcile(request)
+
+		// then
+		assert.NoError(t, err)
+		job = &virtuslabv1alpha1.JenkinsJob{}
+		err = fakeClient.Get(context.TODO(), request.NamespacedName, job)
+		assert.NoError(t, err)
+		assert.True(t, job.Status.Created)
+		assert.Equal(t, "jenkins-2", job.Status.JenkinsRef)
+		assert.Equal(t, "build", job.Status.Path)
+	})
+}
+
+func jenkinsJobCustomResource(name string) *virtuslabv1alpha1.JenkinsJob {
+	isController := true
+	return &virtuslabv1alpha1.JenkinsJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  "default",
+			Finalizers: []string{constants.JenkinsJobFinalizerName},
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: virtuslabv1alpha1.SchemeGroupVersion.String(), Kind: virtuslabv1alpha1.Kind, Name: "jenkins", Controller: &isController},
+			},
+		},
+		Spec: virtuslabv1alpha1.JenkinsJobSpec{
+			JenkinsRef: "jenkins",
+			ConfigXML:  configXML,
+		},
+	}
+}
+
+func newFakeClient(t *testing.T, job *virtuslabv1alpha1.JenkinsJob) client.Client {
+	fakeClient := fake.NewFakeClient()
+	assert.NoError(t, fakeClient.Create(context.TODO(), jenkinsCustomResource("jenkins")))
+	assert.NoError(t, fakeClient.Create(context.TODO(), job))
+	return fakeClient
+}
+
+func jenkinsCustomResource(name string) *virtuslabv1alpha1.Jenkins {
+	completedTime := metav1.Now()
+	return &virtuslabv1alpha1.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status:     virtuslabv1alpha1.JenkinsStatus{BaseConfigurationCompletedTime: &completedTime},
+	}
+}
+
+func newTestReconciler(k8sClient client.Client, jenkinsClient jenkinsclient.Jenkins) *ReconcileJenkinsJob {
+	return &ReconcileJenkinsJob{
+		client: k8sClient,
+		scheme: scheme.Scheme,
+		events: fakeRecorder{},
+		newJenkinsClient: func(k8sClient client.Client, jenkins *virtuslabv1alpha1.Jenkins, local, minikube bool) (jenkinsclient.Jenkins, error) {
+			return jenkinsClient, nil
+		},
+	}
+}
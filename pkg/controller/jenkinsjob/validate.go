@@ -0,0 +1,68 @@
+package jenkinsjob
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	"github.com/VirtusLab/jenkins-operator/pkg/log"
+
+	"github.com/go-logr/logr"
+)
+
+// validate validates JenkinsJob CR Spec section
+func validate(job *virtuslabv1alpha1.JenkinsJob, logger logr.Logger) bool {
+	valid := true
+	if len(job.Spec.JenkinsRef) == 0 {
+		logger.V(log.VWarn).Info("jenkinsRef can't be empty")
+		valid = false
+	}
+
+	for _, folder := range job.Spec.Folders {
+		if len(folder) == 0 {
+			logger.V(log.VWarn).Info("Folder name can't be empty")
+			valid = false
+		}
+	}
+
+	if len(job.Spec.ConfigXML) > 0 && job.Spec.Pipeline != nil {
+		logger.V(log.VWarn).Info("Only one of configXml and pipeline can be set")
+		return false
+	}
+
+	if job.Spec.Pipeline != nil {
+		return validatePipeline(*job.Spec.Pipeline, logger) && valid
+	}
+
+	if len(job.Spec.ConfigXML) == 0 {
+		logger.V(log.VWarn).Info("One of configXml and pipeline must be set")
+		return false
+	}
+
+	var config struct{}
+	if err := xml.Unmarshal([]byte(job.Spec.ConfigXML), &config); err != nil {
+		logger.V(log.VWarn).Info(fmt.Sprintf("configXml is not valid XML: %s", err))
+		valid = false
+	}
+
+	return valid
+}
+
+func validatePipeline(pipeline virtuslabv1alpha1.JenkinsJobPipeline, logger logr.Logger) bool {
+	if pipeline.ScriptConfigMapRef != nil && len(pipeline.RepositoryURL) > 0 {
+		logger.V(log.VWarn).Info("Only one of pipeline.scriptConfigMapRef and pipeline.repositoryUrl can be set")
+		return false
+	}
+
+	if pipeline.ScriptConfigMapRef == nil && len(pipeline.RepositoryURL) == 0 {
+		logger.V(log.VWarn).Info("One of pipeline.scriptConfigMapRef and pipeline.repositoryUrl must be set")
+		return false
+	}
+
+	if pipeline.ScriptConfigMapRef != nil && (len(pipeline.ScriptConfigMapRef.Name) == 0 || len(pipeline.ScriptConfigMapRef.Key) == 0) {
+		logger.V(log.VWarn).Info("pipeline.scriptConfigMapRef name and key can't be empty")
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,94 @@
+package jenkinsjob
+
+import (
+	"testing"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func TestValidate(t *testing.T) {
+	data := []struct {
+		name           string
+		spec           virtuslabv1alpha1.JenkinsJobSpec
+		expectedResult bool
+	}{
+		{
+			name:           "config XML",
+			spec:           virtuslabv1alpha1.JenkinsJobSpec{JenkinsRef: "example", ConfigXML: "<project><builders/></project>"},
+			expectedResult: true,
+		},
+		{
+			name:           "invalid config XML",
+			spec:           virtuslabv1alpha1.JenkinsJobSpec{JenkinsRef: "example", ConfigXML: "<project>"},
+			expectedResult: false,
+		},
+		{
+			name:           "missing Jenkins reference",
+			spec:           virtuslabv1alpha1.JenkinsJobSpec{ConfigXML: "<project/>"},
+			expectedResult: false,
+		},
+		{
+			name:           "neither config XML nor pipeline",
+			spec:           virtuslabv1alpha1.JenkinsJobSpec{JenkinsRef: "example"},
+			expectedResult: false,
+		},
+		{
+			name: "both config XML and pipeline",
+			spec: virtuslabv1alpha1.JenkinsJobSpec{
+				JenkinsRef: "example",
+				ConfigXML:  "<project/>",
+				Pipeline:   &virtuslabv1alpha1.JenkinsJobPipeline{RepositoryURL: "https://github.com/VirtusLab/jenkins-operator.git"},
+			},
+			expectedResult: false,
+		},
+		{
+			name: "pipeline from config map",
+			spec: virtuslabv1alpha1.JenkinsJobSpec{
+				JenkinsRef: "example",
+				Folders:    []string{"team"},
+				Pipeline: &virtuslabv1alpha1.JenkinsJobPipeline{
+					ScriptConfigMapRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "pipelines"},
+						Key:                  "build.jenkins",
+					},
+				},
+			},
+			expectedResult: true,
+		},
+		{
+			name: "pipeline with both config map and repository",
+			spec: virtuslabv1alpha1.JenkinsJobSpec{
+				JenkinsRef: "example",
+				Pipeline: &virtuslabv1alpha1.JenkinsJobPipeline{
+					ScriptConfigMapRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "pipelines"},
+						Key:                  "build.jenkins",
+					},
+					RepositoryURL: "https://github.com/VirtusLab/jenkins-operator.git",
+				},
+			},
+			expectedResult: false,
+		},
+		{
+			name: "empty folder name",
+			spec: virtuslabv1alpha1.JenkinsJobSpec{
+				JenkinsRef: "example",
+				Folders:    []string{""},
+				Pipeline:   &virtuslabv1alpha1.JenkinsJobPipeline{RepositoryURL: "https://github.com/VirtusLab/jenkins-operator.git"},
+			},
+			expectedResult: false,
+		},
+	}
+
+	logger := logf.ZapLogger(false)
+	for _, testingData := range data {
+		t.Run(testingData.name, func(t *testing.T) {
+			job := &virtuslabv1alpha1.JenkinsJob{Spec: testingData.spec}
+			assert.Equal(t, testingData.expectedResult, validate(job, logger))
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"time"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// DefaultPort is the default port of metrics endpoint
+	DefaultPort = 60000
+
+	// PhaseBase is the base configuration phase of reconciliation
+	PhaseBase = "base"
+	// PhaseUser is the user configuration phase of reconciliation
+	PhaseUser = "user"
+
+	metricsNamespace = "jenkins_operator"
+)
+
+var (
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_duration_seconds",
+		Help:      "Duration of Jenkins CR reconciliation phase",
+	}, []string{"namespace", "jenkins", "phase"})
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_errors_total",
+		Help:      "Number of failed Jenkins CR reconciliation phases",
+	}, []string{"namespace", "jenkins", "phase"})
+	buildResults = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "build_results_total",
+		Help:      "Number of finished builds of jobs run by the operator (seed jobs, groovy scripts, backup) by result",
+	}, []string{"namespace", "jenkins", "job", "result"})
+	backupSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "backup_success",
+		Help:      "Whether the last completed backup succeeded (1) or failed (0)",
+	}, []string{"namespace", "jenkins"})
+	backupLastSuccessTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "backup_last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful backup",
+	}, []string{"namespace", "jenkins"})
+	masterPodRestarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "master_pod_restarts_total",
+		Help:      "Number of Jenkins master pod restarts initiated by the operator by reason",
+	}, []string{"namespace", "jenkins", "reason"})
+	missingPlugins = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "missing_plugins",
+		Help:      "Number of required plugins which are not installed in Jenkins",
+	}, []string{"namespace", "jenkins"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		reconcileDuration,
+		reconcileErrors,
+		buildResults,
+		backupSuccess,
+		backupLastSuccessTimestamp,
+		masterPodRestarts,
+		missingPlugins,
+	)
+}
+
+// ObserveReconcile records duration of the reconciliation phase started at start and its error
+func ObserveReconcile(jenkins *virtuslabv1alpha1.Jenkins, phase string, start time.Time, err error) {
+	reconcileDuration.WithLabelValues(jenkins.Namespace, jenkins.Name, phase).Observe(time.Since(start).Seconds())
+	if err != nil {
+		reconcileErrors.WithLabelValues(jenkins.Namespace, jenkins.Name, phase).Inc()
+	}
+}
+
+// ObserveBuild records result of the finished build of Jenkins job
+func ObserveBuild(jenkins *virtuslabv1alpha1.Jenkins, jobName string, status virtuslabv1alpha1.BuildStatus) {
+	buildResults.WithLabelValues(jenkins.Namespace, jenkins.Name, jobName, string(status)).Inc()
+}
+
+// SetBackup records result of the last completed backup and time of the last successful one, zero time means
+// there is no successful backup yet
+func SetBackup(jenkins *virtuslabv1alpha1.Jenkins, success bool, lastSuccess time.Time) {
+	value := 0.0
+	if success {
+		value = 1.0
+	}
+	backupSuccess.WithLabelValues(jenkins.Namespace, jenkins.Name).Set(value)
+	if !lastSuccess.IsZero() {
+		backupLastSuccessTimestamp.WithLabelValues(jenkins.Namespace, jenkins.Name).Set(float64(lastSuccess.Unix()))
+	}
+}
+
+// IncMasterPodRestarts records Jenkins master pod restart initiated by the operator
+func IncMasterPodRestarts(jenkins *virtuslabv1alpha1.Jenkins, reason string) {
+	masterPodRestarts.WithLabelValues(jenkins.Namespace, jenkins.Name, reason).Inc()
+}
+
+// SetMissingPlugins records number of required plugins which are not installed in Jenkins
+func SetMissingPlugins(jenkins *virtuslabv1alpha1.Jenkins, count int) {
+	missingPlugins.WithLabelValues(jenkins.Namespace, jenkins.Name).Set(float64(count))
+}
+
+// Forget deletes all metrics of deleted Jenkins CR
+func Forget(jenkins *virtuslabv1alpha1.Jenkins) {
+	for _, vec := range []metricVec{
+		reconcileDuration,
+		reconcileErrors,
+		buildResults,
+		backupSuccess,
+		backupLastSuccessTimestamp,
+		masterPodRestarts,
+		missingPlugins,
+	} {
+		deleteJenkinsMetrics(vec, jenkins)
+	}
+}
+
+// metricVec is implemented by all metric vectors
+type metricVec interface {
+	prometheus.Collector
+	Delete(labels prometheus.Labels) bool
+}
+
+// deleteJenkinsMetrics deletes metrics of the vector with namespace and jenkins labels of Jenkins CR whatever
+// values of other labels are, they are collected first because the vector can't be modified while being collected
+func deleteJenkinsMetrics(vec metricVec, jenkins *virtuslabv1alpha1.Jenkins) {
+	collected := make(chan prometheus.Metric)
+	go func() {
+		vec.Collect(collected)
+		close(collected)
+	}()
+
+	var jenkinsLabels []prometheus.Labels
+	for metric := range collected {
+		dtoMetric := &dto.Metric{}
+		if err := metric.Write(dtoMetric); err != nil {
+			continue
+		}
+		labels := prometheus.Labels{}
+		for _, pair := range dtoMetric.GetLabel() {
+			labels[pair.GetName()] = pair.GetValue()
+		}
+		if labels["namespace"] == jenkins.Namespace && labels["jenkins"] == jenkins.Name {
+			jenkinsLabels = append(jenkinsLabels, labels)
+		}
+	}
+
+	for _, labels := range jenkinsLabels {
+		vec.Delete(labels)
+	}
+}
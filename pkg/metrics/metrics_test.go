@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestObserveReconcile(t *testing.T) {
+	jenkins := jenkinsCustomResource("reconcile")
+
+	ObserveReconcile(jenkins, PhaseBase, time.Now(), nil)
+	ObserveReconcile(jenkins, PhaseBase, time.Now(), errors.New("failed"))
+
+	assert.Equal(t, 0.0, counterValue(t, reconcileErrors.WithLabelValues(jenkins.Namespace, jenkins.Name, PhaseUser)))
+	assert.Equal(t, 1.0, counterValue(t, reconcileErrors.WithLabelValues(jenkins.Namespace, jenkins.Name, PhaseBase)))
+}
+
+func TestSetBackup(t *testing.T) {
+	jenkins := jenkinsCustomResource("backup")
+	lastSuccess := time.Unix(1546300800, 0)
+
+	SetBackup(jenkins, true, lastSuccess)
+	SetBackup(jenkins, false, time.Time{})
+
+	assert.Equal(t, 0.0, gaugeValue(t, backupSuccess.WithLabelValues(jenkins.Namespace, jenkins.Name)))
+	assert.Equal(t, 1546300800.0, gaugeValue(t, backupLastSuccessTimestamp.WithLabelValues(jenkins.Namespace, jenkins.Name)))
+
+	t.Run("forget", func(t *testing.T) {
+		Forget(jenkins)
+
+		assert.False(t, backupSuccess.DeleteLabelValues(jenkins.Namespace, jenkins.Name))
+		assert.False(t, backupLastSuccessTimestamp.DeleteLabelValues(jenkins.Namespace, jenkins.Name))
+	})
+}
+
+func TestForget(t *testing.T) {
+	jenkins := jenkinsCustomResource("forget")
+	otherJenkins := jenkinsCustomResource("other")
+	for _, jenkins := range []*virtuslabv1alpha1.Jenkins{jenkins, otherJenkins} {
+		ObserveReconcile(jenkins, PhaseBase, time.Now(), errors.New("failed"))
+		ObserveBuild(jenkins, "seed-job", virtuslabv1alpha1.BuildSuccessStatus)
+		SetBackup(jenkins, true, time.Unix(1546300800, 0))
+		IncMasterPodRestarts(jenkins, "pod_changed")
+		SetMissingPlugins(jenkins, 1)
+	}
+
+	Forget(jenkins)
+
+	assert.False(t, reconcileDuration.DeleteLabelValues(jenkins.Namespace, jenkins.Name, PhaseBase))
+	assert.False(t, reconcileErrors.DeleteLabelValues(jenkins.Namespace, jenkins.Name, PhaseBase))
+	assert.False(t, buildResults.DeleteLabelValues(jenkins.Namespace, jenkins.Name, "seed-job", string(virtuslabv1alpha1.BuildSuccessStatus)))
+	assert.False(t, backupSuccess.DeleteLabelValues(jenkins.Namespace, jenkins.Name))
+	assert.False(t, backupLastSuccessTimestamp.DeleteLabelValues(jenkins.Namespace, jenkins.Name))
+	assert.False(t, masterPodRestarts.DeleteLabelValues(jenkins.Namespace, jenkins.Name, "pod_changed"))
+	assert.False(t, missingPlugins.DeleteLabelValues(jenkins.Namespace, jenkins.Name))
+	assert.Equal(t, 1.0, counterValue(t, reconcileErrors.WithLabelValues(otherJenkins.Namespace, otherJenkins.Name, PhaseBase)))
+	assert.Equal(t, 1.0, counterValue(t, masterPodRestarts.WithLabelValues(otherJenkins.Namespace, otherJenkins.Name, "pod_changed")))
+	assert.Equal(t, 1.0, gaugeValue(t, missingPlugins.WithLabelValues(otherJenkins.Namespace, otherJenkins.Name)))
+}
+
+func jenkinsCustomResource(name string) *virtuslabv1alpha1.Jenkins {
+	return &virtuslabv1alpha1.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+}
+
+func counterValue(t *testing.T, counter prometheus.Counter) float64 {
+	metric := &dto.Metric{}
+	assert.NoError(t, counter.Write(metric))
+	return metric.GetCounter().GetValue()
+}
+
+func gaugeValue(t *testing.T, gauge prometheus.Gauge) float64 {
+	metric := &dto.Metric{}
+	assert.NoError(t, gauge.Write(metric))
+	return metric.GetGauge().GetValue()
+}
@@ -0,0 +1,73 @@
+package watch
+
+import (
+	"strings"
+
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// GetNamespaces returns namespaces watched by the operator read from comma separated WATCH_NAMESPACE
+// environment variable, empty list means the operator watches all namespaces
+func GetNamespaces() ([]string, error) {
+	watchNamespace, err := k8sutil.GetWatchNamespace()
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseNamespaces(watchNamespace), nil
+}
+
+// ParseNamespaces returns namespaces from comma separated list, empty list means all namespaces
+func ParseNamespaces(watchNamespace string) []string {
+	var namespaces []string
+	for _, namespace := range strings.Split(watchNamespace, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if len(namespace) > 0 {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+
+	return namespaces
+}
+
+// CacheNamespace returns namespace of the manager cache, the manager watches all namespaces
+// when the operator watches more than one namespace and events are filtered by NamespacePredicate
+func CacheNamespace(namespaces []string) string {
+	if len(namespaces) == 1 {
+		return namespaces[0]
+	}
+
+	return ""
+}
+
+// NamespacePredicate filters out events of objects from namespaces not watched by the operator
+func NamespacePredicate(namespaces []string) predicate.Predicate {
+	watched := func(namespace string) bool {
+		if len(namespaces) == 0 {
+			return true
+		}
+		for _, watchedNamespace := range namespaces {
+			if watchedNamespace == namespace {
+				return true
+			}
+		}
+		return false
+	}
+
+	return predicate.Funcs{
+		CreateFunc: func(evt event.CreateEvent) bool {
+			return watched(evt.Meta.GetNamespace())
+		},
+		UpdateFunc: func(evt event.UpdateEvent) bool {
+			return watched(evt.MetaNew.GetNamespace())
+		},
+		DeleteFunc: func(evt event.DeleteEvent) bool {
+			return watched(evt.Meta.GetNamespace())
+		},
+		GenericFunc: func(evt event.GenericEvent) bool {
+			return watched(evt.Meta.GetNamespace())
+		},
+	}
+}
@@ -0,0 +1,51 @@
+package watch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestParseNamespaces(t *testing.T) {
+	assert.Nil(t, ParseNamespaces(""))
+	assert.Equal(t, []string{"default"}, ParseNamespaces("default"))
+	assert.Equal(t, []string{"team-a", "team-b"}, ParseNamespaces("team-a, team-b,"))
+}
+
+func TestCacheNamespace(t *testing.T) {
+	assert.Equal(t, "", CacheNamespace(nil))
+	assert.Equal(t, "default", CacheNamespace([]string{"default"}))
+	assert.Equal(t, "", CacheNamespace([]string{"team-a", "team-b"}))
+}
+
+func TestNamespacePredicate(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "team-a"}}
+	createEvent := event.CreateEvent{Meta: pod, Object: pod}
+	updateEvent := event.UpdateEvent{MetaOld: pod, ObjectOld: pod, MetaNew: pod, ObjectNew: pod}
+	deleteEvent := event.DeleteEvent{Meta: pod, Object: pod}
+
+	t.Run("all namespaces", func(t *testing.T) {
+		predicate := NamespacePredicate(nil)
+
+		assert.True(t, predicate.Create(createEvent))
+		assert.True(t, predicate.Update(updateEvent))
+		assert.True(t, predicate.Delete(deleteEvent))
+	})
+	t.Run("watched namespace", func(t *testing.T) {
+		predicate := NamespacePredicate([]string{"team-a", "team-b"})
+
+		assert.True(t, predicate.Create(createEvent))
+		assert.True(t, predicate.Update(updateEvent))
+		assert.True(t, predicate.Delete(deleteEvent))
+	})
+	t.Run("not watched namespace", func(t *testing.T) {
+		predicate := NamespacePredicate([]string{"team-b"})
+
+		assert.False(t, predicate.Create(createEvent))
+		assert.False(t, predicate.Update(updateEvent))
+		assert.False(t, predicate.Delete(deleteEvent))
+	})
+}
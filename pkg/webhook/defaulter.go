@@ -0,0 +1,37 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	jenkinscontroller "github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins"
+	"github.com/VirtusLab/jenkins-operator/pkg/log"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	admissiontypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+)
+
+// defaulter sets default values of Jenkins CR, the same as the Jenkins controller does in memory
+type defaulter struct {
+	decoder admissiontypes.Decoder
+}
+
+// Handle sets defaults of Jenkins CR from admission request
+func (d *defaulter) Handle(ctx context.Context, req admissiontypes.Request) admissiontypes.Response {
+	jenkins := &virtuslabv1alpha1.Jenkins{}
+	if err := d.decoder.Decode(req, jenkins); err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+
+	defaulted := jenkins.DeepCopy()
+	jenkinscontroller.SetDefaults(defaulted, log.Log.WithValues("cr", jenkins.Name))
+
+	return admission.PatchResponse(jenkins, defaulted)
+}
+
+// InjectDecoder injects the decoder
+func (d *defaulter) InjectDecoder(decoder admissiontypes.Decoder) error {
+	d.decoder = decoder
+	return nil
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestDefaulter_Handle(t *testing.T) {
+	err := virtuslabv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	assert.NoError(t, err)
+	d := &defaulter{decoder: decoder}
+
+	t.Run("defaults are set", func(t *testing.T) {
+		jenkins := &virtuslabv1alpha1.Jenkins{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "virtuslab.com/v1alpha1", Kind: "Jenkins"},
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		}
+
+		response := d.Handle(context.TODO(), newAdmissionRequest(t, admissionv1beta1.Create, jenkins, nil))
+
+		assert.True(t, response.Response.Allowed)
+		patches := map[string]interface{}{}
+		for _, patch := range response.Patches {
+			patches[patch.Path] = patch.Value
+		}
+		assert.Equal(t, constants.DefaultJenkinsMasterImage, patches["/spec/master/image"])
+		assert.Equal(t, virtuslabv1alpha1.JenkinsBackupTypeNoBackup, patches["/spec/backup"])
+		assert.Contains(t, patches, "/spec/master/plugins")
+		assert.Contains(t, patches, "/spec/master/resources/requests")
+		assert.Contains(t, patches, "/spec/master/resources/limits")
+	})
+	t.Run("values set by the user are preserved", func(t *testing.T) {
+		jenkins := &virtuslabv1alpha1.Jenkins{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "virtuslab.com/v1alpha1", Kind: "Jenkins"},
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Spec: virtuslabv1alpha1.JenkinsSpec{
+				Master: virtuslabv1alpha1.JenkinsMaster{Image: "jenkins/jenkins:2.150.1"},
+				Backup: virtuslabv1alpha1.JenkinsBackupTypeAmazonS3,
+			},
+		}
+
+		response := d.Handle(context.TODO(), newAdmissionRequest(t, admissionv1beta1.Create, jenkins, nil))
+
+		assert.True(t, response.Response.Allowed)
+		for _, patch := range response.Patches {
+			assert.NotEqual(t, "/spec/master/image", patch.Path)
+			assert.NotEqual(t, "/spec/backup", patch.Path)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	jenkinscontroller "github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/base"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/configuration/user"
+	"github.com/VirtusLab/jenkins-operator/pkg/log"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	admissiontypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+)
+
+// validator rejects Jenkins CR which doesn't pass validation of base and user configuration
+type validator struct {
+	client  client.Client
+	scheme  *runtime.Scheme
+	decoder admissiontypes.Decoder
+}
+
+// Handle validates Jenkins CR from admission request, Jenkins CR being deleted and updates which don't change
+// the spec, e.g. removal of the finalizer, are always allowed
+func (v *validator) Handle(ctx context.Context, req admissiontypes.Request) admissiontypes.Response {
+	jenkins := &virtuslabv1alpha1.Jenkins{}
+	if err := v.decoder.Decode(req, jenkins); err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+	if jenkins.DeletionTimestamp != nil {
+		return admission.ValidationResponse(true, "")
+	}
+	if len(req.AdmissionRequest.OldObject.Raw) > 0 {
+		oldJenkins := &virtuslabv1alpha1.Jenkins{}
+		if err := json.Unmarshal(req.AdmissionRequest.OldObject.Raw, oldJenkins); err != nil {
+			return admission.ErrorResponse(http.StatusBadRequest, err)
+		}
+		if reflect.DeepEqual(oldJenkins.Spec, jenkins.Spec) {
+			return admission.ValidationResponse(true, "")
+		}
+	}
+	if err := checkUnknownFields(req.AdmissionRequest.Object.Raw); err != nil {
+		return admission.ValidationResponse(false, err.Error())
+	}
+
+	errs, err := v.validate(jenkins)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusInternalServerError, err)
+	}
+	if len(errs) > 0 {
+		return admission.ValidationResponse(false, errs.ToAggregate().Error())
+	}
+
+	return admission.ValidationResponse(true, "")
+}
+
+// validate runs validation of base and user configuration, it returns errors of invalid fields
+func (v *validator) validate(jenkins *virtuslabv1alpha1.Jenkins) (field.ErrorList, error) {
+	jenkins = jenkins.DeepCopy()
+	logger := log.Log.WithValues("cr", jenkins.Name)
+	jenkinscontroller.SetDefaults(jenkins, logger)
+
+	baseConfiguration := base.New(v.client, v.scheme, logger, jenkins, false, false)
+	errs, err := baseConfiguration.Validate(jenkins)
+	if err != nil || len(errs) > 0 {
+		return errs, err
+	}
+
+	userConfiguration := user.New(v.client, nil, logger, jenkins)
+	return userConfiguration.Validate(jenkins)
+}
+
+// checkUnknownFields rejects Jenkins CR spec with fields which are not defined in Jenkins CR types, e.g. typos in field names,
+// CRD validation schema can't reject them before Kubernetes 1.15
+func checkUnknownFields(raw []byte) error {
+	object := struct {
+		Spec json.RawMessage `json:"spec"`
+	}{}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return err
+	}
+	if len(object.Spec) == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(object.Spec))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&virtuslabv1alpha1.JenkinsSpec{}); err != nil {
+		return fmt.Errorf("spec: %s", err)
+	}
+	return nil
+}
+
+// InjectClient injects the client
+func (v *validator) InjectClient(c client.Client) error {
+	v.client = c
+	return nil
+}
+
+// InjectDecoder injects the decoder
+func (v *validator) InjectDecoder(decoder admissiontypes.Decoder) error {
+	v.decoder = decoder
+	return nil
+}
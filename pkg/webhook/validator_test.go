@@ -0,0 +1,157 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	admissiontypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+)
+
+func newAdmissionRequest(t *testing.T, operation admissionv1beta1.Operation, object, oldObject *virtuslabv1alpha1.Jenkins) admissiontypes.Request {
+	request := &admissionv1beta1.AdmissionRequest{Operation: operation}
+	raw, err := json.Marshal(object)
+	assert.NoError(t, err)
+	request.Object = runtime.RawExtension{Raw: raw}
+	if oldObject != nil {
+		raw, err = json.Marshal(oldObject)
+		assert.NoError(t, err)
+		request.OldObject = runtime.RawExtension{Raw: raw}
+	}
+	return admissiontypes.Request{AdmissionRequest: request}
+}
+
+func TestValidator_Handle(t *testing.T) {
+	err := virtuslabv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+	decoder, err := admission.NewDecoder(scheme.Scheme)
+	assert.NoError(t, err)
+	v := &validator{client: fake.NewFakeClient(), scheme: scheme.Scheme, decoder: decoder}
+	// the secret doesn't exist, e.g. it has been deleted before Jenkins CR
+	jenkins := &virtuslabv1alpha1.Jenkins{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "virtuslab.com/v1alpha1", Kind: "Jenkins"},
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default", Finalizers: []string{"finalizer"}},
+		Spec: virtuslabv1alpha1.JenkinsSpec{
+			UserConfiguration: virtuslabv1alpha1.UserConfiguration{Secrets: []virtuslabv1alpha1.SecretRef{{Name: "deleted-secret"}}},
+		},
+	}
+
+	t.Run("create of invalid Jenkins CR is rejected", func(t *testing.T) {
+		response := v.Handle(context.TODO(), newAdmissionRequest(t, admissionv1beta1.Create, jenkins, nil))
+
+		assert.False(t, response.Response.Allowed)
+	})
+	t.Run("update of spec is validated", func(t *testing.T) {
+		updated := jenkins.DeepCopy()
+		updated.Spec.Master.Image = "jenkins/jenkins:lts"
+
+		response := v.Handle(context.TODO(), newAdmissionRequest(t, admissionv1beta1.Update, updated, jenkins))
+
+		assert.False(t, response.Response.Allowed)
+	})
+	t.Run("update without spec change is allowed", func(t *testing.T) {
+		updated := jenkins.DeepCopy()
+		updated.Finalizers = nil
+
+		response := v.Handle(context.TODO(), newAdmissionRequest(t, admissionv1beta1.Update, updated, jenkins))
+
+		assert.True(t, response.Response.Allowed)
+	})
+	t.Run("Jenkins CR being deleted is allowed", func(t *testing.T) {
+		updated := jenkins.DeepCopy()
+		now := metav1.Now()
+		updated.DeletionTimestamp = &now
+		updated.Spec.Master.Image = "jenkins/jenkins:lts"
+
+		response := v.Handle(context.TODO(), newAdmissionRequest(t, admissionv1beta1.Update, updated, jenkins))
+
+		assert.True(t, response.Response.Allowed)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	v := &validator{client: fake.NewFakeClient(), scheme: scheme.Scheme}
+
+	t.Run("defaulted Jenkins CR is valid", func(t *testing.T) {
+		jenkins := &virtuslabv1alpha1.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"}}
+
+		errs, err := v.validate(jenkins)
+
+		assert.NoError(t, err)
+		assert.Empty(t, errs)
+	})
+	t.Run("invalid image", func(t *testing.T) {
+		jenkins := &virtuslabv1alpha1.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Spec: virtuslabv1alpha1.JenkinsSpec{
+				Master: virtuslabv1alpha1.JenkinsMaster{Image: "jenkins/jenkins:"},
+			},
+		}
+
+		errs, err := v.validate(jenkins)
+
+		assert.NoError(t, err)
+		if assert.Len(t, errs, 1) {
+			assert.Equal(t, "spec.master.image", errs[0].Field)
+		}
+	})
+	t.Run("seed job without id", func(t *testing.T) {
+		jenkins := &virtuslabv1alpha1.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Spec: virtuslabv1alpha1.JenkinsSpec{
+				SeedJobs: []virtuslabv1alpha1.SeedJob{{RepositoryURL: "https://github.com/VirtusLab/jenkins-operator-e2e.git"}},
+			},
+		}
+
+		errs, err := v.validate(jenkins)
+
+		assert.NoError(t, err)
+		var fields []string
+		for _, e := range errs {
+			fields = append(fields, e.Field)
+		}
+		assert.Contains(t, fields, "spec.seedJobs[0].id")
+	})
+	t.Run("plugin without version", func(t *testing.T) {
+		jenkins := &virtuslabv1alpha1.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Spec: virtuslabv1alpha1.JenkinsSpec{
+				Master: virtuslabv1alpha1.JenkinsMaster{Plugins: map[string][]string{"slack:2.3": {"workflow-step-api"}}},
+			},
+		}
+
+		errs, err := v.validate(jenkins)
+
+		assert.NoError(t, err)
+		if assert.Len(t, errs, 1) {
+			assert.Equal(t, "spec.master.plugins[slack:2.3][0]", errs[0].Field)
+		}
+	})
+}
+
+func TestCheckUnknownFields(t *testing.T) {
+	t.Run("known fields", func(t *testing.T) {
+		raw := []byte(`{"apiVersion":"virtuslab.com/v1alpha1","kind":"Jenkins","metadata":{"name":"jenkins","managedFields":[]},` +
+			`"spec":{"master":{"image":"jenkins/jenkins:lts"},"seedJobs":[{"id":"jenkins-operator","repositoryUrl":"https://github.com/VirtusLab/jenkins-operator.git"}]}}`)
+
+		assert.NoError(t, checkUnknownFields(raw))
+	})
+	t.Run("typo in field name", func(t *testing.T) {
+		raw := []byte(`{"apiVersion":"virtuslab.com/v1alpha1","kind":"Jenkins","metadata":{"name":"jenkins"},` +
+			`"spec":{"seedJob":[{"id":"jenkins-operator","repositoryUrl":"https://github.com/VirtusLab/jenkins-operator.git"}]}}`)
+
+		err := checkUnknownFields(raw)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "seedJob")
+	})
+}
@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	virtuslabv1alpha1 "github.com/VirtusLab/jenkins-operator/pkg/apis/virtuslab/v1alpha1"
+	"github.com/VirtusLab/jenkins-operator/pkg/controller/jenkins/constants"
+
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/builder"
+)
+
+const (
+	// DefaultPort is the default port of webhook server
+	DefaultPort = 9876
+
+	serverName     = constants.OperatorName + "-webhook"
+	certDir        = "/tmp/" + constants.OperatorName + "-webhook-cert"
+	certSecretName = constants.OperatorName + "-webhook-cert"
+)
+
+// Add creates webhook server which sets defaults and validates Jenkins CR and adds it to the Manager.
+// The server generates its certificate and registers webhook configurations and service on start
+func Add(mgr manager.Manager, namespace string, port int32) error {
+	mutatingWebhook, err := builder.NewWebhookBuilder().
+		Name("mutating.jenkins.virtuslab.com").
+		Mutating().
+		Operations(admissionregistrationv1beta1.Create, admissionregistrationv1beta1.Update).
+		WithManager(mgr).
+		ForType(&virtuslabv1alpha1.Jenkins{}).
+		Handlers(&defaulter{}).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	validatingWebhook, err := builder.NewWebhookBuilder().
+		Name("validating.jenkins.virtuslab.com").
+		Validating().
+		Operations(admissionregistrationv1beta1.Create, admissionregistrationv1beta1.Update).
+		WithManager(mgr).
+		ForType(&virtuslabv1alpha1.Jenkins{}).
+		Handlers(&validator{scheme: mgr.GetScheme()}).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	server, err := webhook.NewServer(serverName, mgr, webhook.ServerOptions{
+		Port:    port,
+		CertDir: certDir,
+		BootstrapOptions: &webhook.BootstrapOptions{
+			Secret: &types.NamespacedName{Namespace: namespace, Name: certSecretName},
+			Service: &webhook.Service{
+				Namespace: namespace,
+				Name:      serverName,
+				Selectors: map[string]string{"name": constants.OperatorName},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return server.Register(mutatingWebhook, validatingWebhook)
+}
@@ -13,9 +13,12 @@ import (
 	"github.com/bndr/gojenkins"
 	framework "github.com/operator-framework/operator-sdk/pkg/test"
 	"k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func getJenkins(t *testing.T, namespace, name string) *virtuslabv1alpha1.Jenkins {
@@ -148,3 +151,24 @@ func restartJenkinsMasterPod(t *testing.T, jenkins *virtuslabv1alpha1.Jenkins) {
 	}
 	t.Log("Jenkins master pod has been restarted")
 }
+
+// deleteJenkinsCRsOnCleanup deletes Jenkins CRs before the namespace is deleted,
+// so jenkins-operator can remove their finalizers while it's still running
+func deleteJenkinsCRsOnCleanup(ctx *framework.TestCtx, namespace string) {
+	ctx.AddCleanupFn(func() error {
+		jenkinsList := &virtuslabv1alpha1.JenkinsList{}
+		if err := framework.Global.Client.List(context.TODO(), &client.ListOptions{Namespace: namespace}, jenkinsList); err != nil {
+			return err
+		}
+		for _, jenkins := range jenkinsList.Items {
+			if err := framework.Global.Client.Delete(context.TODO(), &jenkins); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+
+		return wait.Poll(retryInterval, timeout, func() (bool, error) {
+			err := framework.Global.Client.List(context.TODO(), &client.ListOptions{Namespace: namespace}, jenkinsList)
+			return len(jenkinsList.Items) == 0, err
+		})
+	})
+}
@@ -51,6 +51,7 @@ func setupTest(t *testing.T) (string, *framework.TestCtx) {
 		t.Fatalf("could not get namespace: %v", err)
 	}
 	t.Logf("Test namespace '%s'", namespace)
+	deleteJenkinsCRsOnCleanup(ctx, namespace)
 
 	// wait for jenkins-operator to be ready
 	err = e2eutil.WaitForDeployment(t, framework.Global.KubeClient, namespace, jenkinsOperatorDeploymentName, 1, retryInterval, timeout)
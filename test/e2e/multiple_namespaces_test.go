@@ -0,0 +1,109 @@
+package e2e
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	framework "github.com/operator-framework/operator-sdk/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const watchNamespaceEnvVar = "WATCH_NAMESPACE"
+
+func TestMultipleNamespaces(t *testing.T) {
+	t.Parallel()
+	namespace, ctx := setupTest(t)
+	// Deletes test namespace
+	defer ctx.Cleanup()
+
+	allowOperatorToWatchAllNamespaces(t, ctx, namespace)
+	secondNamespace := createNamespace(t, ctx, namespace+"-second")
+	deleteJenkinsCRsOnCleanup(ctx, secondNamespace)
+	updateWatchNamespace(t, namespace, namespace, secondNamespace)
+
+	createDefaultLimitsForContainersInNamespace(t, namespace)
+	createDefaultLimitsForContainersInNamespace(t, secondNamespace)
+	jenkins := createJenkinsCR(t, namespace)
+	secondJenkins := createJenkinsCR(t, secondNamespace)
+	waitForJenkinsBaseConfigurationToComplete(t, jenkins)
+	waitForJenkinsBaseConfigurationToComplete(t, secondJenkins)
+
+	verifyJenkinsAPIConnection(t, jenkins)
+	verifyJenkinsAPIConnection(t, secondJenkins)
+}
+
+func createNamespace(t *testing.T, ctx *framework.TestCtx, name string) string {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	cleanupOptions := &framework.CleanupOptions{TestContext: ctx, Timeout: timeout, RetryInterval: retryInterval}
+	if err := framework.Global.Client.Create(context.TODO(), namespace, cleanupOptions); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Logf("Namespace '%s' has been created", name)
+	return name
+}
+
+// allowOperatorToWatchAllNamespaces grants the rules of jenkins-operator role cluster wide, the operator
+// watches all namespaces and filters out events from the other ones when it watches more than one namespace
+func allowOperatorToWatchAllNamespaces(t *testing.T, ctx *framework.TestCtx, namespace string) {
+	role, err := framework.Global.KubeClient.RbacV1().Roles(namespace).Get(jenkinsOperatorDeploymentName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cleanupOptions := &framework.CleanupOptions{TestContext: ctx, Timeout: timeout, RetryInterval: retryInterval}
+	clusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: jenkinsOperatorDeploymentName + "-" + namespace},
+		Rules:      role.Rules,
+	}
+	if err := framework.Global.Client.Create(context.TODO(), clusterRole, cleanupOptions); err != nil {
+		t.Fatal(err)
+	}
+
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: jenkinsOperatorDeploymentName + "-" + namespace},
+		Subjects: []rbacv1.Subject{
+			{Kind: rbacv1.ServiceAccountKind, Name: jenkinsOperatorDeploymentName, Namespace: namespace},
+		},
+		RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: clusterRole.Name},
+	}
+	if err := framework.Global.Client.Create(context.TODO(), clusterRoleBinding, cleanupOptions); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func updateWatchNamespace(t *testing.T, namespace string, watchNamespaces ...string) {
+	deployments := framework.Global.KubeClient.AppsV1().Deployments(namespace)
+	deployment, err := deployments.Get(jenkinsOperatorDeploymentName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	container := &deployment.Spec.Template.Spec.Containers[0]
+	for index, env := range container.Env {
+		if env.Name == watchNamespaceEnvVar {
+			container.Env[index] = corev1.EnvVar{Name: watchNamespaceEnvVar, Value: strings.Join(watchNamespaces, ",")}
+		}
+	}
+	if deployment, err = deployments.Update(deployment); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Logf("Waiting for jenkins-operator to watch '%s' namespaces", strings.Join(watchNamespaces, ","))
+	err = wait.Poll(retryInterval, 30*retryInterval, func() (bool, error) {
+		current, err := deployments.Get(jenkinsOperatorDeploymentName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		return current.Status.ObservedGeneration >= deployment.Generation &&
+			current.Status.UpdatedReplicas == 1 && current.Status.Replicas == 1 && current.Status.AvailableReplicas == 1, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}